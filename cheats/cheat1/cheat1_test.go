@@ -61,6 +61,7 @@ func (el *element) ToComposite() *gt.Composite { panic("It's not Composite") }
 func (el *element) ToInversed() *gt.Inversed   { panic("It's not Inversed") }
 func (el *element) ToNamed() *gt.Named         { panic("It's not Named") }
 func (el *element) ToIdentity() *gt.Identity   { panic("It's not Identity") }
+func (el *element) ToVar() *gt.Var             { panic("It's not Var") }
 
 //Checks whether one of two elements was made from other during the proof
 func (el *element) Same(other gt.Element) bool {
@@ -0,0 +1,25 @@
+package cheat2
+
+// Proof decoding JSON of other element into its argument
+
+import (
+	"github.com/algebraic-brain/group_theory/gt"
+	"testing"
+)
+
+func TestMyCheat(t *testing.T) {
+	a, b := gt.NewNamed("a"), gt.NewNamed("b")
+
+	//Try to cheat verifier:
+	//argument is made from $a$ by no steps, so it stays same as $a$ when it turns to $b$
+	proofForth := func(x gt.Element) gt.Element {
+		if err := x.ToNamed().UnmarshalJSON([]byte(`{"op":"named","name":"b"}`)); err != nil {
+			panic(err)
+		}
+		return x
+	}
+
+	if err := gt.CheckForth(a, b, proofForth); err != nil {
+		t.Fatal("My cheat does not work:", err)
+	}
+}
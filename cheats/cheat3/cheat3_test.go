@@ -0,0 +1,37 @@
+package cheat3
+
+// Proof renaming relation step of other presentation in provenance graph of its context
+
+import (
+	"github.com/algebraic-brain/group_theory/gt"
+	"testing"
+)
+
+func TestMyCheat(t *testing.T) {
+	a, b := gt.NewNamed("a"), gt.NewNamed("b")
+	p := gt.MustParsePresentation("<a, b | a*b^-1>")
+	ctx := gt.NewProofContext()
+	id := func(x gt.Element) gt.Element { return x }
+
+	//Try to cheat verifier:
+	//$a = a\cdot e = a\cdot (b^{-1}\cdot b) = (a\cdot b^{-1})\cdot b = e\cdot b = b$ in "p", then relation step is
+	//disguised as Simplify
+	proofForth := func(x gt.Element) gt.Element {
+		y := gt.Unsimplify(x, false).Map(id, func(el gt.Element) gt.Element {
+			return el.ToIdentity().Unannihilate(gt.NewNamed("b"), true)
+		}).Associate()
+		z := y.Map(p.Relation(0, gt.Forward), id).Simplify()
+		for _, e := range ctx.Edges() {
+			for i := range e.Steps {
+				if e.Steps[i].Rule == gt.RuleRelation {
+					e.Steps[i].Rule = gt.RuleSimplify
+				}
+			}
+		}
+		return z
+	}
+
+	if err := ctx.CheckForth(a, b, proofForth); err != nil {
+		t.Fatal("My cheat does not work:", err)
+	}
+}
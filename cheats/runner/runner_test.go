@@ -0,0 +1,129 @@
+package runner
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+//Outcome of cheat
+const (
+	//Cheat package fails to compile against gt
+	DoesNotCompile = "does not compile"
+	//Tests of cheat fail, i.e. gt rejects the proof (tests of cheat pass iff cheat works, see cheat_template.go)
+	NotVerified = "not verified"
+	//Tests of cheat pass: gt is unsound
+	Verified = "verified"
+)
+
+const repo = "github.com/algebraic-brain/group_theory"
+
+//Entry of cheats.json
+type cheat struct {
+	Package string `json:"package"`
+	Author  string `json:"author,omitempty"`
+	Outcome string `json:"outcome"`
+	//Substring of compiler or test output telling why cheat fails
+	Error string `json:"error"`
+}
+
+func readCheats(t *testing.T, dir string) []cheat {
+	f, err := os.Open(filepath.Join(dir, "cheats.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	var cheats []cheat
+	if err := json.NewDecoder(f).Decode(&cheats); err != nil {
+		t.Fatal("cheats.json:", err)
+	}
+	return cheats
+}
+
+//Makes GOPATH with the repository in temporary dir
+func gopath(t *testing.T, root string) string {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "src", filepath.FromSlash(repo))
+	if err := os.MkdirAll(filepath.Dir(src), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(root, src); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+//compiles tests of cheat package and runs them
+func run(t *testing.T, goTool, path, pkg string) (string, []byte) {
+	bin := filepath.Join(t.TempDir(), pkg+".test")
+	build := exec.Command(goTool, "test", "-c", "-o", bin, repo+"/cheats/"+pkg)
+	build.Env = append(os.Environ(), "GOPATH="+path, "GO111MODULE=off", "GOFLAGS=")
+	if out, err := build.CombinedOutput(); err != nil {
+		if _, ok := err.(*exec.ExitError); !ok {
+			t.Fatal(err)
+		}
+		return DoesNotCompile, out
+	}
+	test := exec.Command(bin)
+	out, err := test.CombinedOutput()
+	if err != nil {
+		if _, ok := err.(*exec.ExitError); !ok {
+			t.Fatal(err)
+		}
+		return NotVerified, out
+	}
+	return Verified, out
+}
+
+//Checks that every known cheat has recorded outcome and error: it either fails to compile or is rejected by gt
+func TestCheats(t *testing.T) {
+	if testing.Short() {
+		t.Skip("compiles cheats")
+	}
+	goTool, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("no go tool:", err)
+	}
+	dir, err := filepath.Abs("..")
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := gopath(t, filepath.Dir(dir))
+
+	cheats := readCheats(t, dir)
+	listed := map[string]bool{}
+	for _, c := range cheats {
+		listed[c.Package] = true
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range entries {
+		if e.IsDir() && e.Name() != "runner" && !listed[e.Name()] {
+			t.Errorf("cheat %s is not in cheats.json", e.Name())
+		}
+	}
+
+	for _, c := range cheats {
+		c := c
+		t.Run(c.Package, func(t *testing.T) {
+			if c.Outcome != DoesNotCompile && c.Outcome != NotVerified {
+				t.Fatalf("cheats.json: outcome of %s is %q, expected %q or %q", c.Package, c.Outcome, DoesNotCompile, NotVerified)
+			}
+			if c.Error == "" {
+				t.Fatalf("cheats.json: no error of %s", c.Package)
+			}
+			outcome, out := run(t, goTool, path, c.Package)
+			if outcome != c.Outcome {
+				t.Fatalf("cheat %s: %s, expected %s\n%s", c.Package, outcome, c.Outcome, out)
+			}
+			if !strings.Contains(string(out), c.Error) {
+				t.Fatalf("cheat %s: %s for other reason, expected %q\n%s", c.Package, outcome, c.Error, out)
+			}
+		})
+	}
+}
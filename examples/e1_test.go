@@ -68,3 +68,64 @@ func Test3(t *testing.T) {
 		t.Fatal("proofForth is not verified")
 	}
 }
+
+func Test4(t *testing.T) {
+	//Test $a \cdot b \cdot b^{-1} \cdot c = a\cdot c$ with parsed elements
+	d := gt.MustParse("a*(b*(b^-1*c))")
+	h := gt.MustParse("a*c")
+
+	id := func(a gt.Element) gt.Element { return a }
+
+	proofForth := func(x gt.Element) gt.Element {
+		return x.ToComposite().Map(id, func(el gt.Element) gt.Element {
+			y := el.ToComposite().Associate()
+			return y.Map(func(el gt.Element) gt.Element {
+				return el.ToComposite().Annihilate()
+			}, id).Simplify()
+		})
+	}
+
+	if err := gt.CheckForth(d, h, proofForth); err != nil {
+		t.Fatal("proofForth is not verified:", err)
+	}
+}
+
+func Test5(t *testing.T) {
+	//Test1 and Test3 as flat lists of steps
+	d := gt.MustParse("a*(b*(b^-1*c))")
+	h := gt.MustParse("a*c")
+
+	right := gt.Path{gt.MoveRight}
+	rightLeft := gt.Path{gt.MoveRight, gt.MoveLeft}
+
+	proofForth := gt.Sequence(
+		gt.At(right, gt.Associator),
+		gt.At(rightLeft, gt.Annihilator),
+		gt.At(right, gt.Simplifier),
+	)
+
+	proofBack := gt.Sequence(
+		gt.At(right, gt.Unsimplifier(true)),
+		gt.At(rightLeft, gt.Unannihilator(gt.NewNamed("b"), false)),
+		gt.At(right, gt.Unassociator),
+	)
+
+	if err := gt.Check(d, h, proofForth, proofBack); err != nil {
+		t.Fatal("proof is not verified:", err)
+	}
+}
+
+func Test6(t *testing.T) {
+	//Test $(a\cdot b)^{-1} = b^{-1}\cdot a^{-1}$ with proof found automatically
+	d := gt.MustParse("(a*b)^-1")
+	h := gt.MustParse("b^-1*a^-1")
+
+	proof, err := gt.Prove(d, h)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !gt.Verify(d, h, proof.Forth(), proof.Inverse().Forth()) {
+		t.Fatal("proof is not verified")
+	}
+}
@@ -0,0 +1,286 @@
+package groups
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+//Maximum number of offending triples collected by AxiomError
+const maxFailures = 20
+
+//Group axiom
+type Axiom int
+
+const (
+	//Products are elements of group
+	ClosureAxiom Axiom = iota
+	//There is $e$ such that $e\cdot a = a\cdot e = a$
+	IdentityAxiom
+	//Every element $a$ has $b$ such that $a\cdot b = b\cdot a = e$
+	InverseAxiom
+	//$(a\cdot b)\cdot c = a\cdot (b\cdot c)$
+	AssociativityAxiom
+)
+
+func (a Axiom) String() string {
+	switch a {
+	case ClosureAxiom:
+		return "closure"
+	case IdentityAxiom:
+		return "identity"
+	case InverseAxiom:
+		return "inverses"
+	case AssociativityAxiom:
+		return "associativity"
+	}
+	return fmt.Sprintf("Axiom(%d)", int(a))
+}
+
+//Three elements of Cayley table named in AxiomError
+type Triple struct {
+	A, B, C string
+}
+
+//Cayley table violates group axiom. Triples (at most 20) name offending elements:
+//  - ClosureAxiom: product $A\cdot B = C$ is not element;
+//  - IdentityAxiom: $A\cdot B = C$ where one of A, B is candidate identity and C is not the other, no triples if
+//    there is no candidate (element $x$ with $x\cdot x = x$);
+//  - InverseAxiom: $A$ has no inverse, $B$ is identity, $C$ is empty;
+//  - AssociativityAxiom: $(A\cdot B)\cdot C \neq A\cdot (B\cdot C)$.
+type AxiomError struct {
+	Axiom   Axiom
+	Triples []Triple
+}
+
+func (e *AxiomError) Error() string {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "groups: %s fails", e.Axiom)
+	if e.Axiom == IdentityAxiom && len(e.Triples) == 0 {
+		b.WriteString(": no identity")
+	}
+	for i, t := range e.Triples {
+		if i == 0 {
+			b.WriteString(": ")
+		} else {
+			b.WriteString(", ")
+		}
+		switch e.Axiom {
+		case ClosureAxiom:
+			fmt.Fprintf(&b, "%s*%s = %s is not element", t.A, t.B, t.C)
+		case IdentityAxiom:
+			fmt.Fprintf(&b, "%s*%s = %s", t.A, t.B, t.C)
+		case InverseAxiom:
+			fmt.Fprintf(&b, "%s has no inverse", t.A)
+		case AssociativityAxiom:
+			fmt.Fprintf(&b, "(%s*%s)*%s != %s*(%s*%s)", t.A, t.B, t.C, t.A, t.B, t.C)
+		}
+	}
+	return b.String()
+}
+
+//Group given by Cayley table of named elements. Element i is i-th name.
+type Table struct {
+	names []string
+	index map[string]int
+	mul   [][]int
+	inv   []int
+	id    int
+}
+
+//Creates group from Cayley table: products[i][j] is name of product of i-th and j-th elements. Fails with
+//*AxiomError if table violates group axioms.
+func NewTable(names []string, products [][]string) (*Table, error) {
+	t := &Table{names: append([]string(nil), names...), index: map[string]int{}}
+	for i, name := range names {
+		if _, ok := t.index[name]; ok {
+			return nil, fmt.Errorf("groups: duplicate element %q", name)
+		}
+		t.index[name] = i
+	}
+	if len(names) == 0 {
+		return nil, &AxiomError{Axiom: IdentityAxiom}
+	}
+	if len(products) != len(names) {
+		return nil, fmt.Errorf("groups: %d rows for %d elements", len(products), len(names))
+	}
+
+	closure := &AxiomError{Axiom: ClosureAxiom}
+	t.mul = make([][]int, len(names))
+	for i, row := range products {
+		if len(row) != len(names) {
+			return nil, fmt.Errorf("groups: %d products in row %s of %d elements", len(row), names[i], len(names))
+		}
+		t.mul[i] = make([]int, len(row))
+		for j, p := range row {
+			k, ok := t.index[p]
+			if !ok {
+				closure.add(names[i], names[j], p)
+			}
+			t.mul[i][j] = k
+		}
+	}
+	if len(closure.Triples) > 0 {
+		return nil, closure
+	}
+	for _, check := range []func() *AxiomError{t.checkIdentity, t.checkInverses, t.checkAssociativity} {
+		if err := check(); err != nil {
+			return nil, err
+		}
+	}
+	return t, nil
+}
+
+func (e *AxiomError) add(a, b, c string) {
+	if len(e.Triples) < maxFailures {
+		e.Triples = append(e.Triples, Triple{A: a, B: b, C: c})
+	}
+}
+
+func (t *Table) checkIdentity() *AxiomError {
+	t.id = -1
+	for i := range t.mul {
+		if t.mul[i][i] == i {
+			t.id = i
+			break
+		}
+	}
+	err := &AxiomError{Axiom: IdentityAxiom}
+	if t.id < 0 {
+		return err
+	}
+	for a := range t.mul {
+		if p := t.mul[t.id][a]; p != a {
+			err.add(t.names[t.id], t.names[a], t.names[p])
+		}
+		if p := t.mul[a][t.id]; p != a {
+			err.add(t.names[a], t.names[t.id], t.names[p])
+		}
+	}
+	if len(err.Triples) > 0 {
+		return err
+	}
+	return nil
+}
+
+func (t *Table) checkInverses() *AxiomError {
+	err := &AxiomError{Axiom: InverseAxiom}
+	t.inv = make([]int, len(t.mul))
+	for a := range t.mul {
+		t.inv[a] = -1
+		for b := range t.mul {
+			if t.mul[a][b] == t.id && t.mul[b][a] == t.id {
+				t.inv[a] = b
+				break
+			}
+		}
+		if t.inv[a] < 0 {
+			err.add(t.names[a], t.names[t.id], "")
+		}
+	}
+	if len(err.Triples) > 0 {
+		return err
+	}
+	return nil
+}
+
+func (t *Table) checkAssociativity() *AxiomError {
+	err := &AxiomError{Axiom: AssociativityAxiom}
+	for a := range t.mul {
+		for b := range t.mul {
+			for c := range t.mul {
+				if t.mul[t.mul[a][b]][c] != t.mul[a][t.mul[b][c]] {
+					err.add(t.names[a], t.names[b], t.names[c])
+				}
+			}
+		}
+	}
+	if len(err.Triples) > 0 {
+		return err
+	}
+	return nil
+}
+
+//Reads Cayley table from CSV: header row lists names of elements after empty or any cell, each following row is name
+//of element and names of its products with elements of header in the same order, e.g.
+//  *,e,a
+//  e,e,a
+//  a,a,e
+func ReadCSV(r io.Reader) (*Table, error) {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("groups: empty table")
+	}
+	for _, rec := range records {
+		for i := range rec {
+			rec[i] = strings.TrimSpace(rec[i])
+		}
+	}
+	names := records[0][1:]
+	var products [][]string
+	for i, rec := range records[1:] {
+		if i >= len(names) || rec[0] != names[i] {
+			return nil, fmt.Errorf("groups: row %d is %q, expected rows in order of header %v", i+2, rec[0], names)
+		}
+		products = append(products, rec[1:])
+	}
+	return NewTable(names, products)
+}
+
+//encoded Cayley table: {"elements":["e","a"],"table":[["e","a"],["a","e"]]}
+type jsonTable struct {
+	Elements []string   `json:"elements"`
+	Table    [][]string `json:"table"`
+}
+
+//Reads Cayley table from JSON {"elements":[…],"table":[[…],…]} where table[i][j] is name of product of i-th and
+//j-th elements
+func ReadJSON(r io.Reader) (*Table, error) {
+	var raw jsonTable
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		return nil, err
+	}
+	return NewTable(raw.Elements, raw.Table)
+}
+
+func (t *Table) MarshalJSON() ([]byte, error) {
+	raw := jsonTable{Elements: t.names, Table: make([][]string, len(t.mul))}
+	for i, row := range t.mul {
+		for _, p := range row {
+			raw.Table[i] = append(raw.Table[i], t.names[p])
+		}
+	}
+	return json.Marshal(raw)
+}
+
+func (t *Table) Mul(a, b int) int { return t.mul[a][b] }
+func (t *Table) Inv(a int) int    { return t.inv[a] }
+func (t *Table) Identity() int    { return t.id }
+func (t *Table) Elements() []int  { return elements(len(t.names)) }
+func (t *Table) Order() int       { return len(t.names) }
+
+//returns name of element
+func (t *Table) Name(a int) string {
+	return t.names[a]
+}
+
+//Returns assignment of names of elements to elements so that Eval evaluates named elements of gt as elements of
+//table with same names
+func (t *Table) Assignment() map[string]int {
+	m := make(map[string]int, len(t.index))
+	for name, i := range t.index {
+		m[name] = i
+	}
+	return m
+}
+
+//Lists names of elements, e.g. "{e, a}"
+func (t *Table) String() string {
+	return "{" + strings.Join(t.names, ", ") + "}"
+}
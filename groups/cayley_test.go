@@ -0,0 +1,82 @@
+package groups
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/algebraic-brain/group_theory/gt"
+)
+
+func TestReadCSV(t *testing.T) {
+	src := `*, e, a, b
+e, e, a, b
+a, a, b, e
+b, b, e, a`
+	g, err := ReadCSV(strings.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkAxioms(t, g)
+	if g.Order() != 3 || g.Name(g.Identity()) != "e" || g.Name(g.Inv(1)) != "b" {
+		t.Fatal("wrong table:", g)
+	}
+	x, err := Eval(gt.MustParse("a^-1*b"), g, g.Assignment())
+	if err != nil || g.Name(x) != "a" {
+		t.Fatal("wrong evaluation:", x, err)
+	}
+
+	if _, err := ReadCSV(strings.NewReader("*,e,a\na,a,e\ne,e,a")); err == nil {
+		t.Fatal("rows out of order are accepted")
+	}
+}
+
+func TestReadJSON(t *testing.T) {
+	//Klein four-group
+	src := `{"elements":["1","x","y","z"],"table":[["1","x","y","z"],["x","1","z","y"],["y","z","1","x"],["z","y","x","1"]]}`
+	g, err := ReadJSON(strings.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkAxioms(t, g)
+	data, err := json.Marshal(g)
+	if err != nil || string(data) != src {
+		t.Fatal("wrong encoding:", string(data), err)
+	}
+}
+
+func TestAxiomError(t *testing.T) {
+	tests := []struct {
+		names    []string
+		products [][]string
+		axiom    Axiom
+		triple   Triple
+	}{
+		{[]string{"e", "a"}, [][]string{{"e", "a"}, {"a", "b"}}, ClosureAxiom, Triple{"a", "a", "b"}},
+		{[]string{"a", "b"}, [][]string{{"b", "a"}, {"a", "a"}}, IdentityAxiom, Triple{}},
+		{[]string{"e", "a"}, [][]string{{"e", "e"}, {"a", "e"}}, IdentityAxiom, Triple{"e", "a", "e"}},
+		{[]string{"e", "z"}, [][]string{{"e", "z"}, {"z", "z"}}, InverseAxiom, Triple{"z", "e", ""}},
+		//loop of order 5 where every element is its own inverse
+		{[]string{"0", "1", "2", "3", "4"}, [][]string{
+			{"0", "1", "2", "3", "4"},
+			{"1", "0", "3", "4", "2"},
+			{"2", "4", "0", "1", "3"},
+			{"3", "2", "4", "0", "1"},
+			{"4", "3", "1", "2", "0"},
+		}, AssociativityAxiom, Triple{"1", "1", "2"}},
+	}
+	for _, tt := range tests {
+		_, err := NewTable(tt.names, tt.products)
+		var ae *AxiomError
+		if !errors.As(err, &ae) || ae.Axiom != tt.axiom {
+			t.Fatalf("%v: wrong error %v, expected %s", tt.products, err, tt.axiom)
+		}
+		if tt.triple != (Triple{}) && (len(ae.Triples) == 0 || ae.Triples[0] != tt.triple) {
+			t.Fatalf("%v: wrong triples %v, expected %v", tt.products, ae.Triples, tt.triple)
+		}
+		if !strings.Contains(err.Error(), tt.axiom.String()) {
+			t.Fatal("wrong message:", err)
+		}
+	}
+}
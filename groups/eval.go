@@ -0,0 +1,44 @@
+package groups
+
+import (
+	"fmt"
+
+	"github.com/algebraic-brain/group_theory/gt"
+)
+
+//Evaluates element in group "g" where named elements are mapped to elements of "g" by "assignment": composites are
+//evaluated to products, inversions to inverses and $e$ to identity. Fails if element has named element without
+//value or variable.
+func Eval(el gt.Element, g Group, assignment map[string]int) (int, error) {
+	for name, a := range assignment {
+		if a < 0 || a >= g.Order() {
+			return 0, fmt.Errorf("groups: value %d of %s is not element of group of order %d", a, name, g.Order())
+		}
+	}
+	//values of evaluated subterms
+	var stack []int
+	err := gt.Postorder(el, func(el gt.Element) error {
+		switch c := el.(type) {
+		case *gt.Composite:
+			n := len(stack)
+			stack = append(stack[:n-2], g.Mul(stack[n-2], stack[n-1]))
+		case *gt.Inversed:
+			stack[len(stack)-1] = g.Inv(stack[len(stack)-1])
+		case *gt.Named:
+			a, ok := assignment[c.Name()]
+			if !ok {
+				return fmt.Errorf("groups: no value of %s", c.Name())
+			}
+			stack = append(stack, a)
+		case *gt.Identity:
+			stack = append(stack, g.Identity())
+		default:
+			return fmt.Errorf("groups: can't evaluate %v", el)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return stack[0], nil
+}
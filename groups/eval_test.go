@@ -0,0 +1,60 @@
+package groups
+
+import (
+	"testing"
+
+	"github.com/algebraic-brain/group_theory/gt"
+)
+
+func TestEval(t *testing.T) {
+	//$i\cdot j = k$, $i^2 = -1$
+	q := Quaternion()
+	assignment := map[string]int{"i": 2, "j": 4}
+	tests := []struct {
+		src  string
+		want int
+	}{
+		{"i*j", 6},
+		{"j*i", 7},
+		{"i*i", 1},
+		{"(i*j)^-1*i*j", 0},
+		{"e", 0},
+	}
+	for _, tt := range tests {
+		a, err := Eval(gt.MustParse(tt.src), q, assignment)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if a != tt.want {
+			t.Fatalf("%s is %d, expected %d", tt.src, a, tt.want)
+		}
+	}
+
+	for _, src := range []string{"k", "?x*i"} {
+		if _, err := Eval(gt.MustParse(src), q, assignment); err == nil {
+			t.Fatal(src, "is evaluated")
+		}
+	}
+	if _, err := Eval(gt.MustParse("i"), q, map[string]int{"i": 8}); err == nil {
+		t.Fatal("value out of group is accepted")
+	}
+}
+
+func TestEvalProof(t *testing.T) {
+	//proved equality holds in every group
+	left, right := gt.MustParse("(a*b)^-1"), gt.MustParse("b^-1*a^-1")
+	if _, err := gt.Prove(left, right); err != nil {
+		t.Fatal(err)
+	}
+	g := GL(2, 3)
+	for _, a := range g.Elements() {
+		for _, b := range g.Elements() {
+			assignment := map[string]int{"a": a, "b": b}
+			l, _ := Eval(left, g, assignment)
+			r, _ := Eval(right, g, assignment)
+			if l != r {
+				t.Fatal("proved equality fails for", assignment)
+			}
+		}
+	}
+}
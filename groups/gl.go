@@ -0,0 +1,134 @@
+package groups
+
+import (
+	"fmt"
+)
+
+//group of invertible n x n matrices over $GF(p)$ numbered in order of their codes
+type gl struct {
+	n, p int
+	//matrices in row-major order
+	mats  [][]int
+	index map[int]int
+}
+
+//Returns general linear group $GL(n, p)$ of invertible n x n matrices over $GF(p)$ for prime "p". Elements are
+//numbered in lexicographic order of matrices in row-major order, so identity is not 0.
+func GL(n, p int) Group {
+	if n < 1 || !isPrime(p) {
+		panic(fmt.Sprintf("groups: invalid GL(%d, %d)", n, p))
+	}
+	g := &gl{n: n, p: p, index: map[int]int{}}
+	m := make([]int, n*n)
+	for {
+		if g.invert(m) != nil {
+			g.index[g.code(m)] = len(g.mats)
+			g.mats = append(g.mats, append([]int(nil), m...))
+		}
+		//next matrix
+		i := len(m) - 1
+		for i >= 0 && m[i] == p-1 {
+			m[i] = 0
+			i--
+		}
+		if i < 0 {
+			return g
+		}
+		m[i]++
+	}
+}
+
+func isPrime(p int) bool {
+	if p < 2 {
+		return false
+	}
+	for d := 2; d*d <= p; d++ {
+		if p%d == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func (g *gl) code(m []int) int {
+	c := 0
+	for _, x := range m {
+		c = c*g.p + x
+	}
+	return c
+}
+
+//returns inverse matrix or nil if matrix is singular
+func (g *gl) invert(m []int) []int {
+	n, p := g.n, g.p
+	//Gauss–Jordan elimination of $(m | 1)$
+	a := make([][]int, n)
+	for i := range a {
+		a[i] = make([]int, 2*n)
+		copy(a[i], m[i*n:(i+1)*n])
+		a[i][n+i] = 1
+	}
+	for col := 0; col < n; col++ {
+		pivot := -1
+		for r := col; r < n; r++ {
+			if a[r][col] != 0 {
+				pivot = r
+				break
+			}
+		}
+		if pivot < 0 {
+			return nil
+		}
+		a[col], a[pivot] = a[pivot], a[col]
+		//multiply pivot row by inverse of pivot: $x^{p-2}$
+		inv := 1
+		for i := 0; i < p-2; i++ {
+			inv = inv * a[col][col] % p
+		}
+		for j := range a[col] {
+			a[col][j] = a[col][j] * inv % p
+		}
+		for r := range a {
+			if r != col && a[r][col] != 0 {
+				f := a[r][col]
+				for j := range a[r] {
+					a[r][j] = ((a[r][j]-f*a[col][j])%p + p) % p
+				}
+			}
+		}
+	}
+	res := make([]int, 0, n*n)
+	for _, row := range a {
+		res = append(res, row[n:]...)
+	}
+	return res
+}
+
+func (g *gl) Mul(a, b int) int {
+	n, x, y := g.n, g.mats[a], g.mats[b]
+	m := make([]int, n*n)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			s := 0
+			for k := 0; k < n; k++ {
+				s += x[i*n+k] * y[k*n+j]
+			}
+			m[i*n+j] = s % g.p
+		}
+	}
+	return g.index[g.code(m)]
+}
+
+func (g *gl) Inv(a int) int { return g.index[g.code(g.invert(g.mats[a]))] }
+
+func (g *gl) Identity() int {
+	m := make([]int, g.n*g.n)
+	for i := 0; i < g.n; i++ {
+		m[i*g.n+i] = 1
+	}
+	return g.index[g.code(m)]
+}
+
+func (g *gl) Elements() []int { return elements(len(g.mats)) }
+func (g *gl) Order() int      { return len(g.mats) }
+func (g *gl) String() string  { return fmt.Sprintf("GL(%d,%d)", g.n, g.p) }
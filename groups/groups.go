@@ -0,0 +1,63 @@
+//Package groups is a catalog of finite groups used as concrete models of abstract proofs of package gt
+package groups
+
+import (
+	"fmt"
+)
+
+//Finite group. Its elements are numbers from 0 to Order()-1.
+type Group interface {
+	//Returns product $a\cdot b$
+	Mul(a, b int) int
+	//Returns $a^{-1}$
+	Inv(a int) int
+	//Returns identity element $e$
+	Identity() int
+	//Returns all elements: 0, 1, ..., Order()-1
+	Elements() []int
+	//Returns number of elements
+	Order() int
+}
+
+//returns 0, 1, ..., n-1
+func elements(n int) []int {
+	el := make([]int, n)
+	for i := range el {
+		el[i] = i
+	}
+	return el
+}
+
+//element $(a, b)$ is a*|H| + b
+type directProduct struct {
+	g, h Group
+}
+
+//Returns direct product $G\times H$: element (a, b) is a*H.Order() + b
+func DirectProduct(g, h Group) Group {
+	return &directProduct{g: g, h: h}
+}
+
+func (p *directProduct) split(a int) (int, int) {
+	return a / p.h.Order(), a % p.h.Order()
+}
+
+func (p *directProduct) join(a, b int) int {
+	return a*p.h.Order() + b
+}
+
+func (p *directProduct) Mul(a, b int) int {
+	a1, a2 := p.split(a)
+	b1, b2 := p.split(b)
+	return p.join(p.g.Mul(a1, b1), p.h.Mul(a2, b2))
+}
+
+func (p *directProduct) Inv(a int) int {
+	a1, a2 := p.split(a)
+	return p.join(p.g.Inv(a1), p.h.Inv(a2))
+}
+
+func (p *directProduct) Identity() int   { return p.join(p.g.Identity(), p.h.Identity()) }
+func (p *directProduct) Elements() []int { return elements(p.Order()) }
+func (p *directProduct) Order() int      { return p.g.Order() * p.h.Order() }
+func (p *directProduct) String() string  { return fmt.Sprintf("%v x %v", p.g, p.h) }
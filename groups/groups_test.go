@@ -0,0 +1,103 @@
+package groups
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/algebraic-brain/group_theory/gt"
+)
+
+//checks group axioms on all elements
+func checkAxioms(t *testing.T, g Group) {
+	e := g.Identity()
+	n := g.Order()
+	if len(g.Elements()) != n {
+		t.Fatalf("%v: %d elements, order %d", g, len(g.Elements()), n)
+	}
+	for _, a := range g.Elements() {
+		if g.Mul(a, e) != a || g.Mul(e, a) != a {
+			t.Fatalf("%v: %d*e != %d", g, a, a)
+		}
+		if g.Mul(a, g.Inv(a)) != e || g.Mul(g.Inv(a), a) != e {
+			t.Fatalf("%v: %d*%d^-1 != e", g, a, a)
+		}
+		for _, b := range g.Elements() {
+			ab := g.Mul(a, b)
+			if ab < 0 || ab >= n {
+				t.Fatalf("%v: %d*%d = %d", g, a, b, ab)
+			}
+			for _, c := range g.Elements() {
+				if g.Mul(ab, c) != g.Mul(a, g.Mul(b, c)) {
+					t.Fatalf("%v: (%d*%d)*%d != %d*(%d*%d)", g, a, b, c, a, b, c)
+				}
+			}
+		}
+	}
+}
+
+func TestCatalog(t *testing.T) {
+	tests := []struct {
+		g     Group
+		name  string
+		order int
+	}{
+		{Cyclic(1), "C1", 1},
+		{Cyclic(7), "C7", 7},
+		{Dihedral(1), "D1", 2},
+		{Dihedral(2), "D2", 4},
+		{Dihedral(3), "D3", 6},
+		{Dihedral(5), "D5", 10},
+		{Symmetric(1), "S1", 1},
+		{Symmetric(2), "S2", 2},
+		{Symmetric(4), "S4", 24},
+		{Alternating(1), "A1", 1},
+		{Alternating(2), "A2", 1},
+		{Alternating(4), "A4", 12},
+		{Alternating(5), "A5", 60},
+		{Quaternion(), "Q8", 8},
+		{DirectProduct(Cyclic(2), Symmetric(3)), "C2 x S3", 12},
+		{GL(2, 2), "GL(2,2)", 6},
+		{GL(2, 3), "GL(2,3)", 48},
+		{GL(3, 2), "GL(3,2)", 168},
+	}
+	for _, tt := range tests {
+		if s := fmt.Sprint(tt.g); s != tt.name || tt.g.Order() != tt.order {
+			t.Fatalf("%s is %s of order %d, expected order %d", tt.name, s, tt.g.Order(), tt.order)
+		}
+		if tt.order <= 60 {
+			checkAxioms(t, tt.g)
+		}
+	}
+}
+
+func TestCommutative(t *testing.T) {
+	abelian := func(g Group) bool {
+		for _, a := range g.Elements() {
+			for _, b := range g.Elements() {
+				if g.Mul(a, b) != g.Mul(b, a) {
+					return false
+				}
+			}
+		}
+		return true
+	}
+	if !abelian(DirectProduct(Cyclic(2), Cyclic(3))) || !abelian(Dihedral(2)) || abelian(Quaternion()) || abelian(Dihedral(3)) {
+		t.Fatal("wrong commutativity")
+	}
+}
+
+func TestFromPerms(t *testing.T) {
+	c := Cyclic(6)
+	for _, a := range c.Elements() {
+		for _, b := range c.Elements() {
+			if c.Mul(a, b) != (a+b)%6 {
+				t.Fatalf("%d*%d is %d in C6", a, b, c.Mul(a, b))
+			}
+		}
+	}
+	g := FromPerms(gt.GL2Perms(3))
+	if fmt.Sprint(g) != "GL(2,3)" || g.Order() != 48 {
+		t.Fatal("wrong group of permutations:", g, g.Order())
+	}
+	checkAxioms(t, g)
+}
@@ -0,0 +1,105 @@
+package groups
+
+import (
+	"fmt"
+
+	"github.com/algebraic-brain/group_theory/gt"
+)
+
+//group of permutations numbered in order of elements of gt.PermGroup
+type permGroup struct {
+	name  string
+	perms []gt.Perm
+	index map[string]int
+	id    int
+}
+
+//Returns group of permutations of "g": element i is g.Elements[i], product $a\cdot b$ applies "a" first as
+//gt.Perm.Compose. So groups of this package and groups searched by gt.FindCounterexample are the same.
+func FromPerms(g *gt.PermGroup) Group {
+	return newPermGroup(g.Name, g.Elements)
+}
+
+func newPermGroup(name string, perms []gt.Perm) *permGroup {
+	g := &permGroup{name: name, perms: perms, index: map[string]int{}}
+	for i, p := range perms {
+		g.index[permKey(p)] = i
+		if p.IsIdentity() {
+			g.id = i
+		}
+	}
+	return g
+}
+
+//encodes permutation as string of its images
+func permKey(p gt.Perm) string {
+	b := make([]byte, 0, 2*len(p))
+	for _, j := range p {
+		b = append(b, byte(j>>8), byte(j))
+	}
+	return string(b)
+}
+
+func isEven(p gt.Perm) bool {
+	inversions := 0
+	for i := range p {
+		for j := i + 1; j < len(p); j++ {
+			if p[i] > p[j] {
+				inversions++
+			}
+		}
+	}
+	return inversions%2 == 0
+}
+
+//Returns cyclic group $C_n$ of gt.CyclicPerms: element i is $c^i$ for generating cycle $c$, so product is sum
+//modulo "n"
+func Cyclic(n int) Group {
+	if n < 1 {
+		panic(fmt.Sprintf("groups: invalid order %d of cyclic group", n))
+	}
+	return FromPerms(gt.CyclicPerms(n))
+}
+
+//Returns dihedral group $D_n$ of order 2n of gt.DihedralPerms: symmetries of regular n-gon
+func Dihedral(n int) Group {
+	if n < 1 {
+		panic(fmt.Sprintf("groups: invalid degree %d of dihedral group", n))
+	}
+	return FromPerms(gt.DihedralPerms(n))
+}
+
+//Returns quaternion group $Q_8 = \{\pm 1, \pm i, \pm j, \pm k\}$ of gt.QuaternionPerms: 0 is 1, 1 is -1, 2 is i, 3
+//is -i, 4 is j, 5 is -j, 6 is k and 7 is -k
+func Quaternion() Group {
+	return FromPerms(gt.QuaternionPerms())
+}
+
+//Returns symmetric group $S_n$ of gt.SymmetricPerms
+func Symmetric(n int) Group {
+	if n < 1 {
+		panic(fmt.Sprintf("groups: invalid degree %d of symmetric group", n))
+	}
+	return FromPerms(gt.SymmetricPerms(n))
+}
+
+//Returns alternating group $A_n$ of even permutations of gt.SymmetricPerms in the same order
+func Alternating(n int) Group {
+	if n < 1 {
+		panic(fmt.Sprintf("groups: invalid degree %d of alternating group", n))
+	}
+	var even []gt.Perm
+	for _, p := range gt.SymmetricPerms(n).Elements {
+		if isEven(p) {
+			even = append(even, p)
+		}
+	}
+	return newPermGroup(fmt.Sprintf("A%d", n), even)
+}
+
+func (g *permGroup) Mul(a, b int) int { return g.index[permKey(g.perms[a].Compose(g.perms[b]))] }
+func (g *permGroup) Inv(a int) int    { return g.index[permKey(g.perms[a].Inverse())] }
+func (g *permGroup) Identity() int    { return g.id }
+func (g *permGroup) Elements() []int  { return elements(len(g.perms)) }
+func (g *permGroup) Order() int       { return len(g.perms) }
+func (g *permGroup) String() string   { return g.name }
@@ -0,0 +1,193 @@
+package gt
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+func TestAdoptPanicsOnConstructedElement(t *testing.T) {
+	a := NewNamed("a")
+	b := Compose(a, Inverse(a)).Annihilate()
+	defer func() {
+		if recover() == nil {
+			t.Fatal("element is modified after construction")
+		}
+	}()
+	b.adopt(a.token(), nil)
+}
+
+func TestFailedStepRecordsNothing(t *testing.T) {
+	ctx := NewProofContext()
+	a, b := NewNamed("a"), NewNamed("b")
+	ctx.CheckForth(Compose(a, b), NewIdentity(), Annihilator)
+	if len(ctx.Edges()) != 0 {
+		t.Fatal("failed step is recorded:", ctx.Edges())
+	}
+}
+
+func randomTerm(r *rand.Rand, depth int) Element {
+	if depth == 0 || r.Intn(4) == 0 {
+		if r.Intn(5) == 0 {
+			return NewIdentity()
+		}
+		return NewNamed(string(rune('a' + r.Intn(3))))
+	}
+	if r.Intn(3) == 0 {
+		return Inverse(randomTerm(r, depth-1))
+	}
+	return Compose(randomTerm(r, depth-1), randomTerm(r, depth-1))
+}
+
+func randomPath(r *rand.Rand, el Element) Path {
+	var p Path
+	for r.Intn(3) != 0 {
+		switch c := el.(type) {
+		case *Composite:
+			if r.Intn(2) == 0 {
+				p, el = append(p, MoveLeft), c.left
+			} else {
+				p, el = append(p, MoveRight), c.right
+			}
+		case *Inversed:
+			p, el = append(p, MoveOperand), c.operand
+		default:
+			return p
+		}
+	}
+	return p
+}
+
+func randomRule(r *rand.Rand) func(Element) Element {
+	rules := []func(Element) Element{
+		Associator, Unassociator, Annihilator, Simplifier, Unsimplifier(r.Intn(2) == 0),
+		Unannihilator(randomTerm(r, 2), r.Intn(2) == 0), DoubleInverter, UndoubleInverter, Distributor, Undistributor,
+	}
+	return rules[r.Intn(len(rules))]
+}
+
+//Element made during aliasing test. Elements of the same class are made one from another by steps.
+type aliased struct {
+	el    Element
+	class int
+	text  string
+	steps int
+}
+
+//Makes random proofs mixing steps with accessors, clones, constructors, decoding JSON and closures leaking subterms,
+//and checks that Same() is true exactly for elements made one from another by steps and that no element is changed
+//afterwards.
+func TestAliasingNeverForgesSame(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	var pool []aliased
+	classes := map[Element]int{}
+	add := func(el Element, class int) {
+		if c, ok := classes[el]; ok {
+			if class >= 0 && c != class {
+				t.Fatalf("%v is made by step and by non-step", el)
+			}
+			return
+		}
+		if class < 0 {
+			class = len(classes)
+		}
+		classes[el] = class
+		pool = append(pool, aliased{el: el, class: class, text: fmt.Sprint(el), steps: len(el.history())})
+	}
+	//adds element leaked to closure of Map, so internal subterms get into pool too
+	leak := func(el Element) { add(el, -1) }
+
+	for i := 0; i < 40; i++ {
+		add(randomTerm(r, 4), -1)
+	}
+	for i := 0; i < 1500; i++ {
+		x := pool[r.Intn(len(pool))]
+		switch r.Intn(9) {
+		case 0, 1, 2:
+			rule := At(randomPath(r, x.el), randomRule(r))
+			if y, err := run(rule, x.el); err == nil {
+				add(y, x.class)
+			}
+		case 3:
+			add(x.el.CloneLiteral(), -1)
+		case 4:
+			switch c := x.el.(type) {
+			case *Composite:
+				add(c.Left(), -1)
+				add(c.Right(), -1)
+			case *Inversed:
+				add(c.Operand(), -1)
+			}
+		case 5:
+			y := pool[r.Intn(len(pool))]
+			add(Compose(x.el, y.el), -1)
+			add(Inverse(x.el), -1)
+		case 6, 7:
+			//closures are steps or not, leaking their arguments either way
+			step := true
+			f := func(el Element) Element {
+				leak(el)
+				switch r.Intn(4) {
+				case 0:
+					return el
+				case 1:
+					step = false
+					return el.CloneLiteral()
+				case 2:
+					step = false
+					return randomTerm(r, 2)
+				}
+				y, err := run(At(randomPath(r, el), randomRule(r)), el)
+				if err != nil {
+					return el
+				}
+				return y
+			}
+			switch c := x.el.(type) {
+			case *Composite:
+				y := c.Map(f, f)
+				if step {
+					add(y, x.class)
+				} else {
+					add(y, -1)
+				}
+			case *Inversed:
+				y := c.Map(f)
+				if step {
+					add(y, x.class)
+				} else {
+					add(y, -1)
+				}
+			}
+		case 8:
+			//decoding into constructed element fails, decoding into zero value makes new element
+			y := pool[r.Intn(len(pool))]
+			data, err := json.Marshal(y.el)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := x.el.(json.Unmarshaler).UnmarshalJSON(data); err == nil {
+				t.Fatalf("%s is decoded into %s", y.text, x.text)
+			}
+			var z Composite
+			if json.Unmarshal(data, &z) == nil {
+				add(&z, -1)
+			}
+		}
+	}
+
+	for i, a := range pool {
+		if s := fmt.Sprint(a.el); s != a.text {
+			t.Fatalf("%s is changed to %s", a.text, s)
+		}
+		if len(a.el.history()) != a.steps {
+			t.Fatalf("history of %s is changed", a.text)
+		}
+		for _, b := range pool[i+1:] {
+			if a.el.Same(b.el) != (a.class == b.class) {
+				t.Fatalf("Same(%s, %s) is %v", a.text, b.text, a.el.Same(b.el))
+			}
+		}
+	}
+}
@@ -0,0 +1,536 @@
+package gt
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+)
+
+//Knuth–Bendix completion did not finish within limits
+var ErrCompletionLimit = errors.New("gt: completion limit exceeded")
+
+//Elements are not equal in presented group so there is no proof of their equality
+var ErrNotEqualInPresentation = errors.New("gt: elements are not equal in presented group")
+
+//Default limits of Knuth–Bendix completion
+const (
+	DefaultMaxRules = 200
+	DefaultMaxSteps = 100000
+)
+
+//Limits of Knuth–Bendix completion
+type CompletionOptions struct {
+	//Maximum number of rules of rewriting system, DefaultMaxRules if 0
+	MaxRules int
+	//Maximum number of rewriting steps made during completion, DefaultMaxSteps if 0
+	MaxSteps int
+}
+
+//Confluent rewriting system of presented group: every element has unique normal form in it.
+//Words are ordered by shortlex order where generators are ordered as in presentation and each one is followed by its
+//inversion.
+type RewritingSystem struct {
+	pres  *Presentation
+	order map[letter]int
+	rules []*rwRule
+	//rules by first letter of lhs
+	index map[letter][]*rwRule
+	//length of longest lhs
+	long int
+}
+
+//rule $lhs\to rhs$ of rewriting system and how it is proved
+type rwRule struct {
+	lhs, rhs []letter
+	//relator turned to $e$ by axiom, -1 for other rules
+	relator int
+	//axiom $a\cdot a^{-1}\to e$
+	free bool
+	//rewrites turning lhs to rhs for rules which are not axioms
+	path   []wordStep
+	active bool
+}
+
+//application of rule to subword at "pos", "back" for application of $rhs\to lhs$
+type wordStep struct {
+	rule *rwRule
+	pos  int
+	back bool
+}
+
+func (r *rwRule) String() string {
+	return format(fromWord(r.lhs)) + " -> " + format(fromWord(r.rhs))
+}
+
+//equation $s = t$ with rewrites turning s to t
+type equation struct {
+	s, t []letter
+	path []wordStep
+}
+
+//state of completion
+type completion struct {
+	*RewritingSystem
+	all     []*rwRule
+	pending []equation
+	steps   int
+	//number of active rules
+	active int
+	opts   CompletionOptions
+}
+
+//Runs Knuth–Bendix completion with shortlex order. Fails with ErrCompletionLimit if rewriting system is not
+//confluent within limits.
+func (p *Presentation) Complete(opts CompletionOptions) (*RewritingSystem, error) {
+	if opts.MaxRules == 0 {
+		opts.MaxRules = DefaultMaxRules
+	}
+	if opts.MaxSteps == 0 {
+		opts.MaxSteps = DefaultMaxSteps
+	}
+	c := &completion{RewritingSystem: &RewritingSystem{pres: p, order: map[letter]int{}, index: map[letter][]*rwRule{}}, opts: opts}
+	for i, g := range p.generators {
+		l := letter{name: g}
+		c.order[l], c.order[l.inverse()] = 2*i, 2*i+1
+		for _, w := range [][]letter{{l, l.inverse()}, {l.inverse(), l}} {
+			c.axiom(&rwRule{lhs: w, relator: -1, free: true})
+		}
+	}
+	for i, r := range p.relators {
+		c.axiom(&rwRule{lhs: reduce(r), relator: i})
+	}
+	if err := c.run(); err != nil {
+		return nil, err
+	}
+	for _, r := range c.all {
+		if r.active {
+			c.rules = append(c.rules, r)
+		}
+	}
+	return c.RewritingSystem, nil
+}
+
+//adds equation $lhs = rhs$ proved by axiom
+func (c *completion) axiom(r *rwRule) {
+	c.pending = append(c.pending, equation{s: r.lhs, t: r.rhs, path: []wordStep{{rule: r}}})
+}
+
+func (c *completion) run() error {
+	if err := c.flush(); err != nil {
+		return err
+	}
+	for i := 0; i < len(c.all); i++ {
+		for j := 0; j <= i && c.all[i].active; j++ {
+			if !c.all[j].active {
+				continue
+			}
+			c.overlaps(c.all[i], c.all[j])
+			if i != j {
+				c.overlaps(c.all[j], c.all[i])
+			}
+			if err := c.flush(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+//adds critical pairs of overlaps of suffix of lhs of "r" with prefix of lhs of "q"
+func (c *completion) overlaps(r, q *rwRule) {
+	for k := 1; k < len(r.lhs) && k < len(q.lhs); k++ {
+		pos := len(r.lhs) - k
+		if !equalWords(r.lhs[pos:], q.lhs[:k]) {
+			continue
+		}
+		//$r.lhs\cdot q.lhs[k:]$ rewritten by both rules
+		c.pending = append(c.pending, equation{
+			s:    concatWords(r.rhs, q.lhs[k:]),
+			t:    concatWords(r.lhs[:pos], q.rhs),
+			path: []wordStep{{rule: r, back: true}, {rule: q, pos: pos}},
+		})
+	}
+}
+
+//orients pending equations to rules
+func (c *completion) flush() error {
+	for len(c.pending) > 0 {
+		e := c.pending[len(c.pending)-1]
+		c.pending = c.pending[:len(c.pending)-1]
+		u, su, err := c.reduce(e.s)
+		if err != nil {
+			return err
+		}
+		v, tv, err := c.reduce(e.t)
+		if err != nil {
+			return err
+		}
+		if equalWords(u, v) {
+			continue
+		}
+		//u to s to t to v
+		path := append(append(invertRewrites(su), e.path...), tv...)
+		r := &rwRule{lhs: v, rhs: u, relator: -1, path: invertRewrites(path), active: true}
+		if c.less(v, u) {
+			r = &rwRule{lhs: u, rhs: v, relator: -1, path: path, active: true}
+		}
+		c.add(r)
+		if c.active > c.opts.MaxRules {
+			return fmt.Errorf("%w: more than %d rules", ErrCompletionLimit, c.opts.MaxRules)
+		}
+	}
+	return nil
+}
+
+//adds rule and turns rules reducible by it back to equations
+func (c *completion) add(r *rwRule) {
+	for _, q := range c.all {
+		if q.active && (indexWord(q.lhs, r.lhs) >= 0 || indexWord(q.rhs, r.lhs) >= 0) {
+			q.active = false
+			c.active--
+			c.unindex(q)
+			c.pending = append(c.pending, equation{s: q.lhs, t: q.rhs, path: []wordStep{{rule: q}}})
+		}
+	}
+	c.all = append(c.all, r)
+	c.index[r.lhs[0]] = append(c.index[r.lhs[0]], r)
+	if len(r.lhs) > c.long {
+		c.long = len(r.lhs)
+	}
+	c.active++
+}
+
+//removes inactive rule from index
+func (c *completion) unindex(r *rwRule) {
+	rules := c.index[r.lhs[0]]
+	for i, q := range rules {
+		if q == r {
+			c.index[r.lhs[0]] = append(rules[:i:i], rules[i+1:]...)
+			return
+		}
+	}
+}
+
+//reduces word by active rules counting steps
+func (c *completion) reduce(w []letter) ([]letter, []wordStep, error) {
+	var path []wordStep
+	for from := 0; ; {
+		rw, ok := c.find(w, from)
+		if !ok {
+			return w, path, nil
+		}
+		if c.steps++; c.steps > c.opts.MaxSteps {
+			return nil, nil, fmt.Errorf("%w: more than %d steps", ErrCompletionLimit, c.opts.MaxSteps)
+		}
+		w = rw.apply(w)
+		path = append(path, rw)
+		from = c.restart(rw.pos)
+	}
+}
+
+//returns position from which word rewritten at "pos" may be reducible: subwords left of it are irreducible
+func (s *RewritingSystem) restart(pos int) int {
+	if pos < s.long {
+		return 0
+	}
+	return pos - s.long + 1
+}
+
+//finds leftmost application of active rule to word at or after "from"
+func (s *RewritingSystem) find(w []letter, from int) (wordStep, bool) {
+	for pos := from; pos < len(w); pos++ {
+		for _, r := range s.index[w[pos]] {
+			if len(r.lhs) <= len(w)-pos && equalWords(w[pos:pos+len(r.lhs)], r.lhs) {
+				return wordStep{rule: r, pos: pos}, true
+			}
+		}
+	}
+	return wordStep{}, false
+}
+
+//returns rewritten word
+func (rw wordStep) apply(w []letter) []letter {
+	from, to := rw.sides()
+	return concatWords(w[:rw.pos], to, w[rw.pos+len(from):])
+}
+
+//returns replaced and replacing subwords
+func (rw wordStep) sides() (from, to []letter) {
+	if rw.back {
+		return rw.rule.rhs, rw.rule.lhs
+	}
+	return rw.rule.lhs, rw.rule.rhs
+}
+
+func invertRewrites(path []wordStep) []wordStep {
+	inv := make([]wordStep, len(path))
+	for i, rw := range path {
+		rw.back = !rw.back
+		inv[len(path)-1-i] = rw
+	}
+	return inv
+}
+
+//shortlex order
+func (s *RewritingSystem) less(u, v []letter) bool {
+	if len(u) != len(v) {
+		return len(u) < len(v)
+	}
+	for i := range u {
+		if u[i] != v[i] {
+			return s.order[u[i]] < s.order[v[i]]
+		}
+	}
+	return false
+}
+
+func concatWords(ws ...[]letter) []letter {
+	var w []letter
+	for _, v := range ws {
+		w = append(w, v...)
+	}
+	return w
+}
+
+//returns index of first occurrence of "sub" in "w" or -1
+func indexWord(w, sub []letter) int {
+	for i := 0; i+len(sub) <= len(w); i++ {
+		if equalWords(w[i:i+len(sub)], sub) {
+			return i
+		}
+	}
+	return -1
+}
+
+//returns presentation of rewriting system
+func (s *RewritingSystem) Presentation() *Presentation {
+	return s.pres
+}
+
+//Lists rules one per line, e.g. "b*a -> a*b^-1"
+func (s *RewritingSystem) String() string {
+	var b bytes.Buffer
+	for i, r := range s.rules {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(r.String())
+	}
+	return b.String()
+}
+
+//returns normal form of word of element
+func (s *RewritingSystem) word(el Element) ([]letter, []wordStep, error) {
+	w := reduce(el)
+	for _, l := range w {
+		if _, ok := s.order[l]; !ok {
+			return nil, nil, fmt.Errorf("gt: %s is not generator of %s", format(fromLetter(l)), s.pres)
+		}
+	}
+	var path []wordStep
+	for from := 0; ; {
+		rw, ok := s.find(w, from)
+		if !ok {
+			return w, path, nil
+		}
+		w = rw.apply(w)
+		path = append(path, rw)
+		from = s.restart(rw.pos)
+	}
+}
+
+//Returns normal form of element: right associated composite of generators and their inversions or $e$. Fails if
+//element is not made of generators.
+func (s *RewritingSystem) Normalize(el Element) (Element, error) {
+	w, _, err := s.word(el)
+	if err != nil {
+		return nil, err
+	}
+	return fromWord(w), nil
+}
+
+//Checks whether two elements are equal in presented group
+func (s *RewritingSystem) Equal(a, b Element) (bool, error) {
+	u, _, err := s.word(a)
+	if err != nil {
+		return false, err
+	}
+	v, _, err := s.word(b)
+	if err != nil {
+		return false, err
+	}
+	return equalWords(u, v), nil
+}
+
+//Searches proof that $left = right$ in presented group. Fails with ErrNotEqualInPresentation if elements are not
+//equal. VerifyProof of presentation accepts proof.Forth().
+func (s *RewritingSystem) Prove(left, right Element) (*Proof, error) {
+	pl, err := s.normalizeProof(left)
+	if err != nil {
+		return nil, err
+	}
+	pr, err := s.normalizeProof(right)
+	if err != nil {
+		return nil, err
+	}
+	if !pl.Right.EqualLiteral(pr.Right) {
+		return nil, fmt.Errorf("%w: %s != %s", ErrNotEqualInPresentation, pl.Right, pr.Right)
+	}
+
+	//left to normal form, then back from normal form to right
+	p := &prover{term: pl.Left, pres: s.pres}
+	for _, st := range pl.Steps {
+		p.apply(st.Step)
+	}
+	for _, st := range pr.Inverse().Steps {
+		p.apply(st.Step)
+	}
+	return &Proof{Left: pl.Left, Right: p.term, Steps: p.steps}, nil
+}
+
+//returns proof that $el = Normalize(el)$
+func (s *RewritingSystem) normalizeProof(el Element) (*Proof, error) {
+	w, path, err := s.word(el)
+	if err != nil {
+		return nil, err
+	}
+	p, err := newProver(el)
+	if err != nil {
+		return nil, err
+	}
+	start := p.term
+	p.pres = s.pres
+	p.normalize(nil)
+	r := &realizer{RewritingSystem: s, proofs: map[*rwRule][]ProofStep{}}
+	w = reduce(el)
+	for _, rw := range path {
+		w = r.rewriteAt(p, nil, w, rw)
+	}
+	return &Proof{Left: start, Right: p.term, Steps: p.steps}, nil
+}
+
+//turns rewrites of words to steps of proof on terms
+type realizer struct {
+	*RewritingSystem
+	//proofs of rules from fromWord(lhs) to fromWord(rhs)
+	proofs map[*rwRule][]ProofStep
+}
+
+//applies word step to subterm at "path" which is fromWord(w) and returns rewritten word
+func (r *realizer) rewriteAt(p *prover, path Path, w []letter, rw wordStep) []letter {
+	from, to := rw.sides()
+	x, y := w[:rw.pos], w[rw.pos+len(from):]
+	shape, sub := fromWord(from), path
+	switch {
+	case len(x) > 0 && len(y) > 0:
+		shape, sub = Compose(fromWord(x), Compose(shape, fromWord(y))), path.to(MoveRight).to(MoveLeft)
+	case len(x) > 0:
+		shape, sub = Compose(fromWord(x), shape), path.to(MoveRight)
+	case len(y) > 0:
+		shape, sub = Compose(shape, fromWord(y)), path.to(MoveLeft)
+	}
+	if len(from) > 0 {
+		p.reshape(path, shape)
+	} else if len(x) > 0 && len(y) > 0 {
+		//$x\cdot y$ to $x\cdot (e\cdot y)$
+		p.reshape(path, Compose(fromWord(x), fromWord(y)))
+		p.apply(Step{Rule: RuleUnsimplify, Path: path.to(MoveRight), Left: true})
+	} else if len(x)+len(y) > 0 {
+		p.apply(Step{Rule: RuleUnsimplify, Path: path, Left: len(y) > 0})
+	}
+
+	steps := r.proof(rw.rule)
+	if rw.back {
+		for i := len(steps) - 1; i >= 0; i-- {
+			s := steps[i].inverse()
+			s.Path = append(append(Path{}, sub...), s.Path...)
+			p.apply(s)
+		}
+	} else {
+		for _, s := range steps {
+			s.Path = append(append(Path{}, sub...), s.Path...)
+			p.apply(s.Step)
+		}
+	}
+
+	if len(to) == 0 && len(x) > 0 && len(y) > 0 {
+		p.apply(Step{Rule: RuleSimplify, Path: path.to(MoveRight)})
+	} else if len(to) == 0 && len(x)+len(y) > 0 {
+		p.apply(Step{Rule: RuleSimplify, Path: path})
+	}
+	w = concatWords(x, to, y)
+	p.reshape(path, fromWord(w))
+	return w
+}
+
+//returns proof of rule
+func (r *realizer) proof(rule *rwRule) []ProofStep {
+	if steps, ok := r.proofs[rule]; ok {
+		return steps
+	}
+	p := &prover{term: fromWord(rule.lhs), pres: r.pres}
+	switch {
+	case rule.free:
+		p.apply(Step{Rule: RuleAnnihilate})
+	case rule.relator >= 0:
+		np, err := NormalizeProof(r.pres.relators[rule.relator])
+		if err != nil {
+			panic(err)
+		}
+		for _, s := range np.Inverse().Steps {
+			p.apply(s.Step)
+		}
+		p.apply(Step{Rule: RuleRelation, Relator: rule.relator, Direction: Forward, pres: r.pres})
+	default:
+		w := rule.lhs
+		for _, rw := range rule.path {
+			w = r.rewriteAt(p, nil, w, rw)
+		}
+	}
+	r.proofs[rule] = p.steps
+	return p.steps
+}
+
+//turns subterm at "path" to element "shape" with same sequence of non-composite subterms using Associate and
+//Unassociate
+func (p *prover) reshape(path Path, shape Element) {
+	p.rightComb(path)
+	p.fromRightComb(path, shape)
+}
+
+//turns subterm at "path" to right associated composite
+func (p *prover) rightComb(path Path) {
+	for {
+		c, ok := p.at(path).(*Composite)
+		if !ok {
+			return
+		}
+		if isComposite(c.left) {
+			p.apply(Step{Rule: RuleUnassociate, Path: path})
+			continue
+		}
+		path = path.to(MoveRight)
+	}
+}
+
+//turns right associated composite at "path" to "shape"
+func (p *prover) fromRightComb(path Path, shape Element) {
+	c, ok := shape.(*Composite)
+	if !ok {
+		return
+	}
+	for i := leaves(c.left); i > 1; i-- {
+		p.apply(Step{Rule: RuleAssociate, Path: path})
+	}
+	p.rightComb(path.to(MoveLeft))
+	p.fromRightComb(path.to(MoveLeft), c.left)
+	p.fromRightComb(path.to(MoveRight), c.right)
+}
+
+//returns number of non-composite subterms
+func leaves(el Element) int {
+	if c, ok := el.(*Composite); ok {
+		return leaves(c.left) + leaves(c.right)
+	}
+	return 1
+}
@@ -0,0 +1,109 @@
+package gt
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestComplete(t *testing.T) {
+	p := MustParsePresentation("<a, b | a*a, b*b*b, (a*b)*(a*b)>")
+	s, err := p.Complete(CompletionOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	//normal forms of $S_3$
+	forms := map[string]bool{}
+	for _, src := range []string{"e", "a", "b", "a*b", "b*a", "a*b*a", "b^-1", "b*b*b*b", "a^-1*b^-1*a*b", "(a*b)^-1*b"} {
+		n, err := s.Normalize(MustParse(src))
+		if err != nil {
+			t.Fatal(err)
+		}
+		forms[n.(interface{ String() string }).String()] = true
+	}
+	if len(forms) != 6 {
+		t.Fatal("wrong number of normal forms:", forms)
+	}
+
+	tests := []struct {
+		left, right string
+		equal       bool
+	}{
+		{"b*a", "a*b^-1", true},
+		{"a*b*a", "b^-1", true},
+		{"(a*b)^-1", "a*b", true},
+		{"a*b", "b*a", false},
+		{"a", "b", false},
+	}
+	for _, tt := range tests {
+		left, right := MustParse(tt.left), MustParse(tt.right)
+		proof, err := s.Prove(left, right)
+		if !tt.equal {
+			if !errors.Is(err, ErrNotEqualInPresentation) {
+				t.Fatalf("%s = %s is proved: %v", tt.left, tt.right, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := p.CheckForth(left, right, proof.Forth()); err != nil {
+			t.Fatalf("proof of %s = %s is not verified: %v", tt.left, tt.right, err)
+		}
+		if VerifyForth(left, right, proof.Forth()) {
+			t.Fatalf("proof of %s = %s is verified in free group", tt.left, tt.right)
+		}
+	}
+
+	if _, err := s.Normalize(MustParse("a*c")); err == nil {
+		t.Fatal("unknown generator is normalized")
+	}
+}
+
+func TestCompleteLimit(t *testing.T) {
+	p := MustParsePresentation("<a, b | a*a, b*b*b, (a*b)*(a*b)>")
+	if _, err := p.Complete(CompletionOptions{MaxRules: 3}); !errors.Is(err, ErrCompletionLimit) {
+		t.Fatal("rules limit is ignored:", err)
+	}
+	if _, err := p.Complete(CompletionOptions{MaxSteps: 3}); !errors.Is(err, ErrCompletionLimit) {
+		t.Fatal("steps limit is ignored:", err)
+	}
+
+	//infinite groups: completion doesn't terminate and fails fast with default limits
+	for _, src := range []string{"<a, b | a*b*a^-1*b^-1*b^-1>", "<a, b, c | a*b*c*a^-1*b^-1*c^-1>"} {
+		start := time.Now()
+		if _, err := MustParsePresentation(src).Complete(CompletionOptions{}); !errors.Is(err, ErrCompletionLimit) {
+			t.Fatalf("completion of %s: %v", src, err)
+		}
+		if d := time.Since(start); d > 5*time.Second {
+			t.Fatalf("completion of %s fails in %v", src, d)
+		}
+	}
+}
+
+func TestProveInTrivialGroup(t *testing.T) {
+	//trivial group with long derivations of rules $a\to e$ and $b\to e$
+	p := MustParsePresentation("<a, b | a*b*a^-1*b^-1*b^-1, b*a*b^-1*a^-1*a^-1>")
+	s, err := p.Complete(CompletionOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range [][2]string{{"a*b", "b*a"}, {"a*a", "e"}, {"b*a*b", "a"}} {
+		left, right := MustParse(c[0]), MustParse(c[1])
+		start := time.Now()
+		proof, err := s.Prove(left, right)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if d := time.Since(start); d > 5*time.Second {
+			t.Fatalf("proof that %s = %s takes %s", left, right, d)
+		}
+		if len(proof.Steps) > 10000 {
+			t.Fatalf("proof that %s = %s has %d steps", left, right, len(proof.Steps))
+		}
+		if err := p.Replay(left, right, proof.Derivation()); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package gt
+
+import (
+	"sync"
+)
+
+//Identity of element shared by elements made one from another by steps. Tokens are compared by address, so they
+//can't be forged and are made without global locks.
+type token struct {
+	//context of proof which owns token, nil for elements made outside of verified proofs
+	ctx *ProofContext
+}
+
+//Edge of provenance graph: element "To" is made from element "From" by "Steps" (with paths relative to "To")
+type Edge struct {
+	From  Element
+	To    Element
+	Steps []ProofStep
+}
+
+//Context of verified proofs: owns tokens of arguments of proofs and records provenance graph, i.e. which steps made
+//which element from which. Result of proof is verified only if the graph leads to it from argument of proof.
+//Contexts are independent, so proofs in different contexts run in parallel without contention.
+type ProofContext struct {
+	mu    sync.Mutex
+	edges []Edge
+	//edge to element
+	made map[Element]int
+}
+
+//Creates empty context
+func NewProofContext() *ProofContext {
+	return &ProofContext{made: map[Element]int{}}
+}
+
+//records that "to" is made from "from" by steps
+func (ctx *ProofContext) record(from, to Element, steps []ProofStep) {
+	if ctx == nil {
+		return
+	}
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	ctx.made[to] = len(ctx.edges)
+	ctx.edges = append(ctx.edges, Edge{From: from, To: to, Steps: steps})
+}
+
+//returns steps of provenance graph from "from" to "to" if there is path between them
+func (ctx *ProofContext) provenance(from, to Element) ([]ProofStep, bool) {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	var path []int
+	for el := to; el != from; {
+		i, ok := ctx.made[el]
+		if !ok {
+			return nil, false
+		}
+		path = append(path, i)
+		el = ctx.edges[i].From
+	}
+	var steps []ProofStep
+	for i := len(path) - 1; i >= 0; i-- {
+		steps = appendSteps(steps, ctx.edges[path[i]].Steps...)
+	}
+	return steps, true
+}
+
+//Returns copy of recorded provenance graph in order of steps, changing it doesn't change the graph
+func (ctx *ProofContext) Edges() []Edge {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	edges := make([]Edge, len(ctx.edges))
+	for i, e := range ctx.edges {
+		steps := make([]ProofStep, len(e.Steps))
+		for j, s := range e.Steps {
+			s.Path = append(Path(nil), s.Path...)
+			steps[j] = s
+		}
+		edges[i] = Edge{From: e.From, To: e.To, Steps: steps}
+	}
+	return edges
+}
+
+//Verify proof (forth, back) that $left = right$ recording its provenance graph
+func (ctx *ProofContext) Verify(left, right Element, forth, back func(Element) Element) bool {
+	return ctx.Check(left, right, forth, back) == nil
+}
+
+//Verify proof "forth" that $left = right$ recording its provenance graph
+func (ctx *ProofContext) VerifyForth(left, right Element, forth func(Element) Element) bool {
+	return ctx.CheckForth(left, right, forth) == nil
+}
+
+//Same as Verify but reports why the proof failed. Returns nil or *VerifyError.
+func (ctx *ProofContext) Check(left, right Element, forth, back func(Element) Element) error {
+	if err := ctx.CheckForth(left, right, forth); err != nil {
+		return err
+	}
+	_, err := check(ctx, right, left, back, "back", nil)
+	return err
+}
+
+//Same as VerifyForth but reports why the proof failed. Returns nil or *VerifyError.
+func (ctx *ProofContext) CheckForth(left, right Element, forth func(Element) Element) error {
+	_, err := check(ctx, left, right, forth, "forth", nil)
+	return err
+}
@@ -0,0 +1,101 @@
+package gt
+
+import (
+	"errors"
+	"sync"
+	"testing"
+)
+
+func TestProofContextEdges(t *testing.T) {
+	a, b, c := NewNamed("a"), NewNamed("b"), NewNamed("c")
+	left := Compose(a, Compose(b, Compose(Inverse(b), c)))
+	ctx := NewProofContext()
+	forth := Sequence(At(Path{MoveRight}, Associator), At(Path{MoveRight, MoveLeft}, Annihilator), At(Path{MoveRight}, Simplifier))
+	if err := ctx.CheckForth(left, Compose(a, c), forth); err != nil {
+		t.Fatal(err)
+	}
+	edges := ctx.Edges()
+	if len(edges) != 3 {
+		t.Fatal("wrong provenance graph:", edges)
+	}
+	for i := 1; i < len(edges); i++ {
+		if edges[i].From != edges[i-1].To {
+			t.Fatal("provenance graph is not connected at edge", i)
+		}
+	}
+	if !edges[2].To.EqualLiteral(Compose(a, c)) || edges[2].Steps[0].Rule != RuleSimplify {
+		t.Fatal("wrong last edge:", edges[2])
+	}
+}
+
+func TestForgedTokenIsNotStep(t *testing.T) {
+	a, b := NewNamed("a"), NewNamed("b")
+	err := CheckForth(a, b, func(x Element) Element {
+		n := NewNamed("b")
+		n.adopt(x.token(), nil)
+		return n
+	})
+	var ve *VerifyError
+	if !errors.As(err, &ve) || ve.Kind != NotStep {
+		t.Fatal("element with forged token is accepted:", err)
+	}
+}
+
+func TestParallelProofs(t *testing.T) {
+	left, right := MustParse("a*(b*(b^-1*c))"), MustParse("a*c")
+	proof, err := Prove(left, right)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var wg sync.WaitGroup
+	errs := make(chan error, 16)
+	for i := 0; i < cap(errs); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				if err := Check(left, right, proof.Forth(), proof.Inverse().Forth()); err != nil {
+					errs <- err
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Fatal(err)
+	}
+}
+
+func TestEdgesDoNotChangeProvenance(t *testing.T) {
+	a, b := NewNamed("a"), NewNamed("b")
+	p := MustParsePresentation("<a, b | a*b^-1>")
+	ctx := NewProofContext()
+	//$a = a\cdot e = a\cdot (b^{-1}\cdot b) = (a\cdot b^{-1})\cdot b = e\cdot b = b$ renaming relation step to Simplify
+	forth := func(x Element) Element {
+		y := Unsimplify(x, false).Map(func(el Element) Element { return el }, func(el Element) Element {
+			return el.ToIdentity().Unannihilate(NewNamed("b"), true)
+		}).Associate()
+		z := y.Map(p.Relation(0, Forward), func(el Element) Element { return el }).Simplify()
+		for _, e := range ctx.Edges() {
+			for i := range e.Steps {
+				if e.Steps[i].Rule == RuleRelation {
+					e.Steps[i].Rule, e.Steps[i].Path = RuleSimplify, Path{MoveLeft}
+				}
+			}
+		}
+		return z
+	}
+	var ve *VerifyError
+	if err := ctx.CheckForth(a, b, forth); !errors.As(err, &ve) || ve.Kind != NotInPresentation {
+		t.Fatal("proof renaming relation step is accepted:", err)
+	}
+	for _, e := range ctx.Edges() {
+		for _, s := range e.Steps {
+			if s.Rule == RuleSimplify && len(s.Path) > 0 {
+				t.Fatal("provenance graph is changed:", e)
+			}
+		}
+	}
+}
@@ -0,0 +1,302 @@
+package gt
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+)
+
+//Coset enumeration did not finish within limit
+var ErrCosetLimit = errors.New("gt: coset limit exceeded")
+
+//Default limit of number of cosets defined by CosetEnumerate
+const DefaultCosetLimit = 1000000
+
+//Action of generators of presented group on right cosets of subgroup. Cosets are numbered from 0, coset 0 is the
+//subgroup itself.
+type CosetTable struct {
+	pres *Presentation
+	//table[i][2*j] is coset i times generator j, table[i][2*j+1] is coset i times inversion of generator j
+	table [][]int
+}
+
+//Enumerates right cosets of subgroup generated by "subgroup" in presented group by Todd–Coxeter procedure (HLT
+//strategy). Fails with ErrCosetLimit if more than "limit" cosets are defined (DefaultCosetLimit if "limit" is 0),
+//e.g. if index of subgroup is infinite.
+func CosetEnumerate(p *Presentation, subgroup []Element, limit int) (*CosetTable, error) {
+	if limit <= 0 {
+		limit = DefaultCosetLimit
+	}
+	e := &enumeration{limit: limit, columns: columns(p)}
+	gens := make([][]int, len(subgroup))
+	for i, el := range subgroup {
+		w, err := e.word(el)
+		if err != nil {
+			return nil, err
+		}
+		gens[i] = w
+	}
+	rels := make([][]int, len(p.relators))
+	for i, r := range p.relators {
+		rels[i], _ = e.word(r)
+	}
+
+	if err := e.define(-1, 0); err != nil {
+		return nil, err
+	}
+	for _, w := range gens {
+		if err := e.scanAndFill(0, w); err != nil {
+			return nil, err
+		}
+	}
+	for a := 0; a < len(e.table); a++ {
+		for _, r := range rels {
+			if !e.live(a) {
+				break
+			}
+			if err := e.scanAndFill(a, r); err != nil {
+				return nil, err
+			}
+		}
+		for x := 0; x < 2*len(p.generators) && e.live(a); x++ {
+			if e.table[a][x] < 0 {
+				if err := e.define(a, x); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	return e.compact(p), nil
+}
+
+//Returns order of presented group found by coset enumeration of trivial subgroup. Fails with ErrCosetLimit if more
+//than "limit" cosets are defined (DefaultCosetLimit if "limit" is 0), e.g. if group is infinite.
+func (p *Presentation) Order(limit int) (int, error) {
+	t, err := CosetEnumerate(p, nil, limit)
+	if err != nil {
+		return 0, err
+	}
+	return t.Index(), nil
+}
+
+//state of Todd–Coxeter procedure
+type enumeration struct {
+	limit   int
+	columns map[letter]int
+	table   [][]int
+	//representatives of cosets, parent[i] == i for live cosets
+	parent []int
+	queue  []int
+}
+
+//returns columns of coset table: 2*j for generator j and 2*j+1 for its inversion
+func columns(p *Presentation) map[letter]int {
+	c := map[letter]int{}
+	for i, g := range p.generators {
+		c[letter{name: g}], c[letter{name: g, inv: true}] = 2*i, 2*i+1
+	}
+	return c
+}
+
+//returns columns of word of element
+func (e *enumeration) word(el Element) ([]int, error) {
+	var w []int
+	for _, l := range reduce(el) {
+		x, ok := e.columns[l]
+		if !ok {
+			return nil, fmt.Errorf("gt: %s is not generator", format(fromLetter(l)))
+		}
+		w = append(w, x)
+	}
+	return w, nil
+}
+
+func (e *enumeration) live(a int) bool {
+	return e.parent[a] == a
+}
+
+//defines new coset as coset "a" times column "x" or first coset if "a" is -1
+func (e *enumeration) define(a, x int) error {
+	if len(e.table) >= e.limit {
+		return fmt.Errorf("%w: more than %d cosets", ErrCosetLimit, e.limit)
+	}
+	b := len(e.table)
+	row := make([]int, len(e.columns))
+	for i := range row {
+		row[i] = -1
+	}
+	e.table = append(e.table, row)
+	e.parent = append(e.parent, b)
+	if a >= 0 {
+		e.table[a][x], e.table[b][x^1] = b, a
+	}
+	return nil
+}
+
+//traces word from coset "a" forth and back defining cosets until "a" times word is "a"
+func (e *enumeration) scanAndFill(a int, w []int) error {
+	f, b := a, a
+	i, j := 0, len(w)-1
+	for {
+		for i <= j && e.table[f][w[i]] >= 0 {
+			f = e.table[f][w[i]]
+			i++
+		}
+		if i > j {
+			if f != b {
+				e.coincidence(f, b)
+			}
+			return nil
+		}
+		for j >= i && e.table[b][w[j]^1] >= 0 {
+			b = e.table[b][w[j]^1]
+			j--
+		}
+		if j < i {
+			e.coincidence(f, b)
+			return nil
+		}
+		if i == j {
+			//deduction
+			e.table[f][w[i]], e.table[b][w[i]^1] = b, f
+			return nil
+		}
+		if err := e.define(f, w[i]); err != nil {
+			return err
+		}
+	}
+}
+
+func (e *enumeration) rep(a int) int {
+	r := a
+	for e.parent[r] != r {
+		r = e.parent[r]
+	}
+	for e.parent[a] != r {
+		a, e.parent[a] = e.parent[a], r
+	}
+	return r
+}
+
+//merges classes of cosets "a" and "b" queuing dead coset
+func (e *enumeration) merge(a, b int) {
+	a, b = e.rep(a), e.rep(b)
+	if a == b {
+		return
+	}
+	if a > b {
+		a, b = b, a
+	}
+	e.parent[b] = a
+	e.queue = append(e.queue, b)
+}
+
+//identifies cosets "a" and "b" and all cosets which follow
+func (e *enumeration) coincidence(a, b int) {
+	e.queue = e.queue[:0]
+	e.merge(a, b)
+	for i := 0; i < len(e.queue); i++ {
+		d := e.queue[i]
+		for x, f := range e.table[d] {
+			if f < 0 {
+				continue
+			}
+			e.table[f][x^1] = -1
+			d1, f1 := e.rep(d), e.rep(f)
+			switch {
+			case e.table[d1][x] >= 0:
+				e.merge(f1, e.table[d1][x])
+			case e.table[f1][x^1] >= 0:
+				e.merge(d1, e.table[f1][x^1])
+			default:
+				e.table[d1][x], e.table[f1][x^1] = f1, d1
+			}
+		}
+	}
+}
+
+//renumbers live cosets
+func (e *enumeration) compact(p *Presentation) *CosetTable {
+	index := make([]int, len(e.table))
+	n := 0
+	for a := range e.table {
+		if e.live(a) {
+			index[a] = n
+			n++
+		}
+	}
+	t := &CosetTable{pres: p}
+	for a, row := range e.table {
+		if !e.live(a) {
+			continue
+		}
+		r := make([]int, len(row))
+		for x, b := range row {
+			r[x] = index[e.rep(b)]
+		}
+		t.table = append(t.table, r)
+	}
+	return t
+}
+
+//returns number of cosets, i.e. index of subgroup
+func (t *CosetTable) Index() int {
+	return len(t.table)
+}
+
+//returns presentation of coset table
+func (t *CosetTable) Presentation() *Presentation {
+	return t.pres
+}
+
+//Returns coset "coset" times element. Fails if element is not made of generators.
+func (t *CosetTable) Act(coset int, el Element) (int, error) {
+	e := &enumeration{columns: columns(t.pres)}
+	w, err := e.word(el)
+	if err != nil {
+		return 0, err
+	}
+	for _, x := range w {
+		coset = t.table[coset][x]
+	}
+	return coset, nil
+}
+
+//Returns permutation of cosets by generator: coset i times generator is coset perm[i]. Returns nil if there is no
+//such generator.
+func (t *CosetTable) Permutation(generator string) Perm {
+	for j, g := range t.pres.generators {
+		if g == generator {
+			perm := make(Perm, len(t.table))
+			for i, row := range t.table {
+				perm[i] = row[2*j]
+			}
+			return perm
+		}
+	}
+	return nil
+}
+
+//Returns permutation representation of presented group on cosets, suitable for Eval
+func (t *CosetTable) Permutations() map[string]Perm {
+	perms := map[string]Perm{}
+	for _, g := range t.pres.generators {
+		perms[g] = t.Permutation(g)
+	}
+	return perms
+}
+
+//Formats table with row per coset and column per generator
+func (t *CosetTable) String() string {
+	var b bytes.Buffer
+	for _, g := range t.pres.generators {
+		b.WriteString("\t" + g)
+	}
+	for i, row := range t.table {
+		fmt.Fprintf(&b, "\n%d:", i)
+		for j := range t.pres.generators {
+			fmt.Fprintf(&b, "\t%d", row[2*j])
+		}
+	}
+	return b.String()
+}
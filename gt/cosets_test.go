@@ -0,0 +1,76 @@
+package gt
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCosetEnumerate(t *testing.T) {
+	tests := []struct {
+		pres     string
+		subgroup []string
+		index    int
+	}{
+		{"<a, b | a*a, b*b*b, (a*b)*(a*b)>", nil, 6},
+		{"<a, b | a*a, b*b*b, (a*b)*(a*b)>", []string{"a"}, 3},
+		{"<a, b | a*a, b*b*b, (a*b)*(a*b)>", []string{"b"}, 2},
+		{"<a, b | a*a, b*b*b, (a*b)*(a*b)*(a*b)*(a*b)>", nil, 24},
+		{"<a, b | a*a*a*a*a*a*a, b*b*b, b^-1*a*b*a^-1*a^-1>", nil, 21},
+		{"<a, b | a*b*a^-1*b^-1*a, b*a*b^-1*a^-1*b>", nil, 1},
+	}
+	for _, tt := range tests {
+		p := MustParsePresentation(tt.pres)
+		var subgroup []Element
+		for _, src := range tt.subgroup {
+			subgroup = append(subgroup, MustParse(src))
+		}
+		table, err := CosetEnumerate(p, subgroup, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if table.Index() != tt.index {
+			t.Fatalf("%s: index of %v is %d, expected %d", tt.pres, tt.subgroup, table.Index(), tt.index)
+		}
+		for _, r := range p.Relators() {
+			for i := 0; i < table.Index(); i++ {
+				if j, _ := table.Act(i, r); j != i {
+					t.Fatalf("%s: relator %s moves coset %d", tt.pres, r, i)
+				}
+			}
+		}
+		for _, h := range subgroup {
+			if j, _ := table.Act(0, h); j != 0 {
+				t.Fatalf("%s: %s moves subgroup", tt.pres, h)
+			}
+		}
+	}
+}
+
+func TestCosetPermutation(t *testing.T) {
+	p := MustParsePresentation("<a, b | a*a, b*b*b, (a*b)*(a*b)>")
+	table, err := CosetEnumerate(p, []Element{NewNamed("a")}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	perm := table.Permutation("b")
+	seen := map[int]bool{}
+	for _, j := range perm {
+		seen[j] = true
+	}
+	if len(perm) != 3 || len(seen) != 3 || perm[perm[perm[0]]] != 0 || perm[0] == 0 {
+		t.Fatal("wrong permutation:", perm)
+	}
+	if table.Permutation("c") != nil {
+		t.Fatal("permutation of unknown generator")
+	}
+}
+
+func TestCosetLimit(t *testing.T) {
+	p := MustParsePresentation("<a, b | a*b*a^-1*b^-1>")
+	if _, err := p.Order(100); !errors.Is(err, ErrCosetLimit) {
+		t.Fatal("infinite group has order:", err)
+	}
+	if n, err := MustParsePresentation("<a | a*a*a*a*a>").Order(0); err != nil || n != 5 {
+		t.Fatal("wrong order:", n, err)
+	}
+}
@@ -0,0 +1,246 @@
+package gt
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"sort"
+)
+
+//Default maximum number of assignments tried in each group by FindCounterexample
+const DefaultMaxAssignments = 10000
+
+//Finite group of permutations given by all its elements
+type PermGroup struct {
+	Name     string
+	Elements []Perm
+}
+
+//Creates permutation group generated by permutations of same degree
+func NewPermGroup(name string, degree int, generators ...Perm) *PermGroup {
+	id := IdentityPerm(degree)
+	g := &PermGroup{Name: name, Elements: []Perm{id}}
+	seen := map[string]bool{fmt.Sprint([]int(id)): true}
+	for i := 0; i < len(g.Elements); i++ {
+		for _, s := range generators {
+			p := g.Elements[i].Compose(s)
+			if k := fmt.Sprint([]int(p)); !seen[k] {
+				seen[k] = true
+				g.Elements = append(g.Elements, p)
+			}
+		}
+	}
+	return g
+}
+
+//Returns cyclic group of order "n" acting on "n" points
+func CyclicPerms(n int) *PermGroup {
+	return NewPermGroup(fmt.Sprintf("C%d", n), n, cycle(n))
+}
+
+//Returns dihedral group of order 2n: symmetries of regular n-gon. For n < 3 reflection of n-gon is rotation or
+//identity, so the group acts on n+2 points and reflection also swaps points n and n+1.
+func DihedralPerms(n int) *PermGroup {
+	degree := n
+	if n < 3 {
+		degree = n + 2
+	}
+	rot, r := IdentityPerm(degree), IdentityPerm(degree)
+	copy(rot, cycle(n))
+	for i := 0; i < n; i++ {
+		r[i] = (n - i) % n
+	}
+	if n < 3 {
+		r[n], r[n+1] = n+1, n
+	}
+	return NewPermGroup(fmt.Sprintf("D%d", n), degree, rot, r)
+}
+
+//Returns symmetric group of degree "n"
+func SymmetricPerms(n int) *PermGroup {
+	if n == 1 {
+		return NewPermGroup("S1", 1)
+	}
+	return NewPermGroup(fmt.Sprintf("S%d", n), n, cycle(n), MustFromCycles(n, []int{0, 1}))
+}
+
+//Returns quaternion group $Q_8$ acting on itself by right multiplication. Element number y is multiplication by
+//$\pm u$ where y = 2*u + s, units $1, i, j, k$ are 0, 1, 2, 3 and sign s is 1 for minus.
+func QuaternionPerms() *PermGroup {
+	//units 1, i, j, k are 0, 1, 2, 3; unit u with sign s is 2*u + s
+	units := [4][4]int{
+		{0, 2, 4, 6},
+		{2, 1, 6, 5},
+		{4, 7, 1, 2},
+		{6, 4, 3, 1},
+	}
+	mul := func(x, y int) int {
+		return units[x/2][y/2] ^ (x % 2) ^ (y % 2)
+	}
+	right := func(y int) Perm {
+		p := make(Perm, 8)
+		for x := range p {
+			p[x] = mul(x, y)
+		}
+		return p
+	}
+	g := NewPermGroup("Q8", 8, right(2), right(4))
+	//multiplication by y maps 1 to y
+	sort.Slice(g.Elements, func(i, j int) bool { return g.Elements[i][0] < g.Elements[j][0] })
+	return g
+}
+
+//Returns general linear group $GL(2, p)$ of invertible 2x2 matrices over $GF(p)$ for prime "p" acting on nonzero row
+//vectors by right multiplication
+func GL2Perms(p int) *PermGroup {
+	//vector (x, y) is point x*p + y - 1
+	n := p*p - 1
+	matrix := func(a, b, c, d int) Perm {
+		m := make(Perm, n)
+		for i := range m {
+			x, y := (i+1)/p, (i+1)%p
+			m[i] = (x*a+y*c)%p*p + (x*b+y*d)%p - 1
+		}
+		return m
+	}
+	//elementary matrices and diagonal matrices generate $GL(2, p)$
+	var gens []Perm
+	for t := 1; t < p; t++ {
+		gens = append(gens, matrix(1, 1, 0, 1), matrix(1, 0, 1, 1), matrix(t, 0, 0, 1))
+	}
+	return NewPermGroup(fmt.Sprintf("GL(2,%d)", p), n, gens...)
+}
+
+//returns cycle $(0 1 \ldots n-1)$
+func cycle(n int) Perm {
+	c := make([]int, n)
+	for i := range c {
+		c[i] = i
+	}
+	return MustFromCycles(n, c)
+}
+
+//Returns groups searched by FindCounterexample by default: cyclic, dihedral, symmetric $S_3$-$S_5$, quaternion
+//and $GL(2, p)$ in ascending order
+func SmallGroups() []*PermGroup {
+	return []*PermGroup{
+		CyclicPerms(2), CyclicPerms(3), CyclicPerms(4), CyclicPerms(5), CyclicPerms(6),
+		SymmetricPerms(3), DihedralPerms(4), QuaternionPerms(), DihedralPerms(5), DihedralPerms(6),
+		SymmetricPerms(4), GL2Perms(3), SymmetricPerms(5), GL2Perms(5),
+	}
+}
+
+//Options of FindCounterexample
+type CounterexampleOptions struct {
+	//Groups searched in order, SmallGroups() if nil
+	Groups []*PermGroup
+	//Maximum number of assignments tried in each group, DefaultMaxAssignments if 0, must not be negative. If there
+	//are more, assignments are chosen randomly.
+	MaxAssignments int
+}
+
+//Assignment of named elements to permutations such that $left \neq right$
+type Counterexample struct {
+	Group      string
+	Assignment map[string]Perm
+	Left       Perm
+	Right      Perm
+}
+
+func (c *Counterexample) String() string {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "in %s:", c.Group)
+	for i, name := range sortedNames(c.Assignment) {
+		if i > 0 {
+			b.WriteString(",")
+		}
+		fmt.Fprintf(&b, " %s = %s", name, c.Assignment[name])
+	}
+	fmt.Fprintf(&b, ": %s != %s", c.Left, c.Right)
+	return b.String()
+}
+
+//Searches assignment of named elements to permutations of small groups where $left \neq right$. Returns nil if
+//there is no counterexample among tried assignments. Fails if elements have variables.
+func FindCounterexample(left, right Element, opts CounterexampleOptions) (*Counterexample, error) {
+	if opts.Groups == nil {
+		opts.Groups = SmallGroups()
+	}
+	if opts.MaxAssignments == 0 {
+		opts.MaxAssignments = DefaultMaxAssignments
+	}
+	if opts.MaxAssignments < 0 {
+		return nil, fmt.Errorf("gt: negative number of assignments %d", opts.MaxAssignments)
+	}
+	set := map[string]Perm{}
+	if err := namesOf(left, set); err != nil {
+		return nil, err
+	}
+	if err := namesOf(right, set); err != nil {
+		return nil, err
+	}
+	names := sortedNames(set)
+	rnd := rand.New(rand.NewSource(1))
+
+	for _, g := range opts.Groups {
+		size := 1
+		for range names {
+			if size *= len(g.Elements); size > opts.MaxAssignments {
+				break
+			}
+		}
+		for k := 0; k < size && k < opts.MaxAssignments; k++ {
+			assignment := map[string]Perm{}
+			for i, n := 0, k; i < len(names); i++ {
+				j := n % len(g.Elements)
+				n /= len(g.Elements)
+				if size > opts.MaxAssignments {
+					j = rnd.Intn(len(g.Elements))
+				}
+				assignment[names[i]] = g.Elements[j]
+			}
+			l, err := Eval(left, assignment)
+			if err != nil {
+				return nil, err
+			}
+			r, err := Eval(right, assignment)
+			if err != nil {
+				return nil, err
+			}
+			if !l.Equal(r) {
+				return &Counterexample{Group: g.Name, Assignment: assignment, Left: l, Right: r}, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+//collects names of named elements
+func namesOf(el Element, names map[string]Perm) error {
+	switch c := el.(type) {
+	case *Composite:
+		if err := namesOf(c.left, names); err != nil {
+			return err
+		}
+		return namesOf(c.right, names)
+	case *Inversed:
+		return namesOf(c.operand, names)
+	case *Named:
+		names[c.name] = nil
+	case *Identity:
+	case *Var:
+		return fmt.Errorf("gt: can't assign variable %s", format(c))
+	default:
+		return fmt.Errorf("gt: can't assign element of type %T", el)
+	}
+	return nil
+}
+
+func sortedNames(m map[string]Perm) []string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,73 @@
+package gt
+
+import (
+	"testing"
+)
+
+func TestSmallGroups(t *testing.T) {
+	orders := map[string]int{"C5": 5, "S3": 6, "D4": 8, "Q8": 8, "D6": 12, "S4": 24, "GL(2,3)": 48, "S5": 120, "GL(2,5)": 480}
+	for _, g := range SmallGroups() {
+		if n, ok := orders[g.Name]; ok && len(g.Elements) != n {
+			t.Fatalf("order of %s is %d, expected %d", g.Name, len(g.Elements), n)
+		}
+	}
+	for _, g := range []*PermGroup{DihedralPerms(1), DihedralPerms(2), DihedralPerms(3), SymmetricPerms(1), SymmetricPerms(2)} {
+		if n := map[string]int{"D1": 2, "D2": 4, "D3": 6, "S1": 1, "S2": 2}[g.Name]; len(g.Elements) != n {
+			t.Fatalf("order of %s is %d, expected %d", g.Name, len(g.Elements), n)
+		}
+	}
+	//$Q_8$ has single element of order 2
+	q8 := QuaternionPerms()
+	involutions := 0
+	for _, p := range q8.Elements {
+		if !p.IsIdentity() && p.Compose(p).IsIdentity() {
+			involutions++
+		}
+	}
+	if involutions != 1 {
+		t.Fatal("wrong quaternion group:", involutions, "involutions")
+	}
+}
+
+func TestFindCounterexample(t *testing.T) {
+	tests := []struct {
+		left, right string
+		group       string
+	}{
+		{"a*b", "b*a", "S3"},
+		{"a*a", "e", "C3"},
+		{"(a*b)^-1", "a^-1*b^-1", "S3"},
+		{"a*a*b*b", "b*b*a*a", "S4"},
+		{"a*a*a*a*a*a", "e", "C4"},
+		{"a*b", "a*b", ""},
+		{"(a*b)^-1", "b^-1*a^-1", ""},
+	}
+	for _, tt := range tests {
+		left, right := MustParse(tt.left), MustParse(tt.right)
+		c, err := FindCounterexample(left, right, CounterexampleOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tt.group == "" {
+			if c != nil {
+				t.Fatalf("counterexample to %s = %s: %s", tt.left, tt.right, c)
+			}
+			continue
+		}
+		if c == nil || c.Group != tt.group {
+			t.Fatalf("counterexample to %s = %s: %v, expected in %s", tt.left, tt.right, c, tt.group)
+		}
+		l, _ := Eval(left, c.Assignment)
+		r, _ := Eval(right, c.Assignment)
+		if l.Equal(r) {
+			t.Fatal("wrong counterexample:", c)
+		}
+	}
+
+	if _, err := FindCounterexample(MustParse("?x"), NewIdentity(), CounterexampleOptions{}); err == nil {
+		t.Fatal("variable is assigned")
+	}
+	if c, err := FindCounterexample(MustParse("a*b"), MustParse("b*a"), CounterexampleOptions{MaxAssignments: -1}); err == nil {
+		t.Fatal("negative number of assignments is accepted:", c)
+	}
+}
@@ -0,0 +1,142 @@
+package gt
+
+//Names of derived steps of proof
+const (
+	RuleDoubleInverse           = "DoubleInverse"
+	RuleUndoubleInverse         = "UndoubleInverse"
+	RuleDistributeOverProduct   = "DistributeOverProduct"
+	RuleUndistributeOverProduct = "UndistributeOverProduct"
+)
+
+func identity(el Element) Element { return el }
+
+//turns $(a^{-1})^{-1}$ to $a$. This is a step of proof made of primitive steps.
+func (c *Inversed) DoubleInverse() Element {
+	n, err := c.TryDoubleInverse()
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+//Same as DoubleInverse but returns *StepError instead of panicking
+func (c *Inversed) TryDoubleInverse() (Element, error) {
+	in, ok := c.operand.(*Inversed)
+	if !ok {
+		return nil, &StepError{Step: "DoubleInverse", Pattern: `$(a^{-1})^{-1}$`, Term: c}
+	}
+	a := in.operand
+	//$e\cdot (a^{-1})^{-1}$
+	n := Unsimplify(c, true)
+	//$(a\cdot a^{-1})\cdot (a^{-1})^{-1}$
+	n = n.Map(Unannihilator(a, false), identity)
+	//$a\cdot (a^{-1}\cdot (a^{-1})^{-1})$
+	n = n.Unassociate()
+	//$a\cdot e$
+	n = n.Map(identity, Annihilator)
+	return n.Simplify(), nil
+}
+
+//turns $a$ to $(a^{-1})^{-1}$. This is a step of proof made of primitive steps.
+func UndoubleInverse(el Element) *Inversed {
+	//$a\cdot e$
+	n := Unsimplify(el, false)
+	//$a\cdot (a^{-1}\cdot (a^{-1})^{-1})$
+	n = n.Map(identity, Unannihilator(Inverse(el), false))
+	//$(a\cdot a^{-1})\cdot (a^{-1})^{-1}$
+	n = n.Associate()
+	//$e\cdot (a^{-1})^{-1}$
+	n = n.Map(Annihilator, identity)
+	return n.Simplify().(*Inversed)
+}
+
+//turns $(a\cdot b)^{-1}$ to $b^{-1}\cdot a^{-1}$. This is a step of proof made of primitive steps.
+func (c *Inversed) DistributeOverProduct() *Composite {
+	n, err := c.TryDistributeOverProduct()
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+//Same as DistributeOverProduct but returns *StepError instead of panicking
+func (c *Inversed) TryDistributeOverProduct() (*Composite, error) {
+	p, ok := c.operand.(*Composite)
+	if !ok {
+		return nil, &StepError{Step: "DistributeOverProduct", Pattern: `$(a\cdot b)^{-1}$`, Term: c}
+	}
+	a, b := p.left, p.right
+	//$x\cdot e$ where $x = (a\cdot b)^{-1}$
+	n := Unsimplify(c, false)
+	//$x\cdot (a\cdot a^{-1})$
+	n = n.Map(identity, Unannihilator(a, false))
+	//$x\cdot (a\cdot (e\cdot a^{-1}))$
+	n = n.Map(identity, At(Path{MoveRight}, Unsimplifier(true)))
+	//$x\cdot (a\cdot ((b\cdot b^{-1})\cdot a^{-1}))$
+	n = n.Map(identity, At(Path{MoveRight, MoveLeft}, Unannihilator(b, false)))
+	//$x\cdot (a\cdot (b\cdot (b^{-1}\cdot a^{-1})))$
+	n = n.Map(identity, At(Path{MoveRight}, Unassociator))
+	//$x\cdot ((a\cdot b)\cdot (b^{-1}\cdot a^{-1}))$
+	n = n.Map(identity, Associator)
+	//$(x\cdot (a\cdot b))\cdot (b^{-1}\cdot a^{-1})$
+	n = n.Associate()
+	//$e\cdot (b^{-1}\cdot a^{-1})$
+	n = n.Map(Annihilator, identity)
+	return n.Simplify().(*Composite), nil
+}
+
+//turns $b^{-1}\cdot a^{-1}$ to $(a\cdot b)^{-1}$. This is a step of proof made of primitive steps.
+func (c *Composite) UndistributeOverProduct() *Inversed {
+	n, err := c.TryUndistributeOverProduct()
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+//Same as UndistributeOverProduct but returns *StepError instead of panicking
+func (c *Composite) TryUndistributeOverProduct() (*Inversed, error) {
+	l, lok := c.left.(*Inversed)
+	r, rok := c.right.(*Inversed)
+	if !lok || !rok {
+		return nil, &StepError{Step: "UndistributeOverProduct", Pattern: `$b^{-1}\cdot a^{-1}$`, Term: c}
+	}
+	a, b := r.operand, l.operand
+	//$e\cdot y$ where $y = b^{-1}\cdot a^{-1}$
+	n := Unsimplify(c, true)
+	//$(x\cdot (a\cdot b))\cdot y$ where $x = (a\cdot b)^{-1}$
+	n = n.Map(Unannihilator(Compose(a, b), true), identity)
+	//$x\cdot ((a\cdot b)\cdot y)$
+	n = n.Unassociate()
+	//$x\cdot (a\cdot (b\cdot (b^{-1}\cdot a^{-1})))$
+	n = n.Map(identity, Unassociator)
+	//$x\cdot (a\cdot ((b\cdot b^{-1})\cdot a^{-1}))$
+	n = n.Map(identity, At(Path{MoveRight}, Associator))
+	//$x\cdot (a\cdot (e\cdot a^{-1}))$
+	n = n.Map(identity, At(Path{MoveRight, MoveLeft}, Annihilator))
+	//$x\cdot (a\cdot a^{-1})$
+	n = n.Map(identity, At(Path{MoveRight}, Simplifier))
+	//$x\cdot e$
+	n = n.Map(identity, Annihilator)
+	return n.Simplify().(*Inversed), nil
+}
+
+//DoubleInverse as proof. This is a step of proof.
+func DoubleInverter(el Element) Element {
+	return el.ToInversed().DoubleInverse()
+}
+
+//UndoubleInverse as proof. This is a step of proof.
+func UndoubleInverter(el Element) Element {
+	return UndoubleInverse(el)
+}
+
+//DistributeOverProduct as proof. This is a step of proof.
+func Distributor(el Element) Element {
+	return el.ToInversed().DistributeOverProduct()
+}
+
+//UndistributeOverProduct as proof. This is a step of proof.
+func Undistributor(el Element) Element {
+	return el.ToComposite().UndistributeOverProduct()
+}
@@ -0,0 +1,82 @@
+package gt
+
+import (
+	"testing"
+)
+
+func TestDoubleInverse(t *testing.T) {
+	a := MustParse("a*b")
+	b := UndoubleInverse(a)
+	c := b.DoubleInverse()
+
+	if !b.Same(a) || !c.Same(b) {
+		t.Fatal("DoubleInverse or UndoubleInverse is not a step")
+	}
+	if !b.EqualLiteral(MustParse("(a*b)^-1^-1")) || !c.EqualLiteral(a) {
+		t.Fatal("DoubleInverse and UndoubleInverse are not inverse of each other:", b, c)
+	}
+	if _, err := Inverse(a).TryDoubleInverse(); err == nil {
+		t.Fatal("DoubleInverse accepts wrong argument")
+	}
+}
+
+func TestDistributeOverProduct(t *testing.T) {
+	a := MustParse("(a*b^-1)^-1").(*Inversed)
+	b := a.DistributeOverProduct()
+	c := b.UndistributeOverProduct()
+
+	if !b.Same(a) || !c.Same(b) {
+		t.Fatal("DistributeOverProduct or UndistributeOverProduct is not a step")
+	}
+	if !b.EqualLiteral(MustParse("b^-1^-1*a^-1")) || !c.EqualLiteral(a) {
+		t.Fatal("DistributeOverProduct and UndistributeOverProduct are not inverse of each other:", b, c)
+	}
+	if _, err := MustParse("a^-1^-1").(*Inversed).TryDistributeOverProduct(); err == nil {
+		t.Fatal("DistributeOverProduct accepts wrong argument")
+	}
+	if _, err := MustParse("a*b^-1").(*Composite).TryUndistributeOverProduct(); err == nil {
+		t.Fatal("UndistributeOverProduct accepts wrong argument")
+	}
+}
+
+func TestDerivedStepsAreVerified(t *testing.T) {
+	left := MustParse("(a*b)^-1^-1*c")
+	right := MustParse("(c^-1*(a*b)^-1)^-1")
+
+	forth := Sequence(
+		At(Path{MoveLeft}, DoubleInverter),
+		UndoubleInverter,
+		At(Path{MoveOperand}, Distributor),
+	)
+	p, err := VerifyProof(left, right, forth)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range p.Steps {
+		switch s.Rule {
+		case RuleAssociate, RuleUnassociate, RuleAnnihilate, RuleUnannihilate, RuleSimplify, RuleUnsimplify:
+		default:
+			t.Fatal("derived step is not recorded as primitive ones:", s)
+		}
+	}
+	if err := Replay(left, right, p.Derivation()); err != nil {
+		t.Fatal(err)
+	}
+
+	steps := []Step{
+		{Rule: RuleDoubleInverse, Path: Path{MoveLeft}},
+		{Rule: RuleUndoubleInverse},
+		{Rule: RuleDistributeOverProduct, Path: Path{MoveOperand}},
+		{Rule: RuleUndistributeOverProduct, Path: Path{MoveOperand}},
+	}
+	if err := Replay(left, MustParse("((a*b)*c)^-1^-1"), steps); err != nil {
+		t.Fatal(err)
+	}
+	q := &Proof{Left: left, Steps: make([]ProofStep, len(steps))}
+	for i, s := range steps {
+		q.Steps[i].Step = s
+	}
+	if err := Check(left, MustParse("((a*b)*c)^-1^-1"), q.Forth(), q.Inverse().Forth()); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,36 @@
+package gt
+
+import (
+	"fmt"
+)
+
+//Pretty-prints the first differing subterm of two elements. Returns empty string if they are equal literally.
+func Diff(expected, actual Element) string {
+	path, e, a := firstDiff(expected, actual, nil)
+	if e == nil {
+		return ""
+	}
+	return fmt.Sprintf("at %s:\n- %s\n+ %s", path, format(e), format(a))
+}
+
+//finds first (in pre-order) pair of subterms that differ at the top
+func firstDiff(expected, actual Element, path Path) (Path, Element, Element) {
+	switch e := expected.(type) {
+	case *Composite:
+		if a, ok := actual.(*Composite); ok {
+			if p, de, da := firstDiff(e.left, a.left, append(path, MoveLeft)); de != nil {
+				return p, de, da
+			}
+			return firstDiff(e.right, a.right, append(path, MoveRight))
+		}
+	case *Inversed:
+		if a, ok := actual.(*Inversed); ok {
+			return firstDiff(e.operand, a.operand, append(path, MoveOperand))
+		}
+	default:
+		if expected.EqualLiteral(actual) {
+			return nil, nil, nil
+		}
+	}
+	return path, expected, actual
+}
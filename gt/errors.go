@@ -0,0 +1,131 @@
+package gt
+
+import (
+	"fmt"
+)
+
+//Error of a step of proof applied to an element of wrong type
+type StepError struct {
+	//Name of the step, e.g. "Associate"
+	Step string
+	//Type of arguments required by the step, e.g. $a\cdot (b\cdot c)$
+	Pattern string
+	//Element the step was applied to (nil if unknown)
+	Term Element
+}
+
+func (e *StepError) Error() string {
+	if e.Term == nil {
+		return fmt.Sprintf("%s requires %s type arguments", e.Step, e.Pattern)
+	}
+	return fmt.Sprintf("%s requires %s type arguments, got %s", e.Step, e.Pattern, format(e.Term))
+}
+
+func castError(typ string, el Element) *StepError {
+	return &StepError{Step: "To" + typ, Pattern: typ, Term: el}
+}
+
+//Casts element to *Composite or returns *StepError
+func AsComposite(el Element) (*Composite, error) {
+	if c, ok := el.(*Composite); ok {
+		return c, nil
+	}
+	return nil, castError("Composite", el)
+}
+
+//Casts element to *Inversed or returns *StepError
+func AsInversed(el Element) (*Inversed, error) {
+	if c, ok := el.(*Inversed); ok {
+		return c, nil
+	}
+	return nil, castError("Inversed", el)
+}
+
+//Casts element to *Named or returns *StepError
+func AsNamed(el Element) (*Named, error) {
+	if c, ok := el.(*Named); ok {
+		return c, nil
+	}
+	return nil, castError("Named", el)
+}
+
+//Casts element to *Identity or returns *StepError
+func AsIdentity(el Element) (*Identity, error) {
+	if c, ok := el.(*Identity); ok {
+		return c, nil
+	}
+	return nil, castError("Identity", el)
+}
+
+//Casts element to *Var or returns *StepError
+func AsVar(el Element) (*Var, error) {
+	if c, ok := el.(*Var); ok {
+		return c, nil
+	}
+	return nil, castError("Var", el)
+}
+
+//Reason of proof verification failure
+type FailureKind int
+
+const (
+	//Proof failed to run, e.g. a step was applied to element of wrong type
+	ProofFailed FailureKind = iota
+	//Result of proof is not made from its argument by steps
+	NotStep
+	//Result of proof is not literally equal to expected element
+	NotEqual
+	//Proof applies relation of presentation other than one it is verified in
+	NotInPresentation
+	//Element or its subterm is not of type of this package, e.g. it embeds *Named to override its methods
+	ForeignElement
+)
+
+func (k FailureKind) String() string {
+	switch k {
+	case ProofFailed:
+		return "proof failed"
+	case NotStep:
+		return "not step"
+	case NotEqual:
+		return "not equal"
+	case NotInPresentation:
+		return "not in presentation"
+	case ForeignElement:
+		return "foreign element"
+	}
+	return fmt.Sprintf("FailureKind(%d)", int(k))
+}
+
+//Failure of proof verification returned by Check and CheckForth
+type VerifyError struct {
+	Kind FailureKind
+	//Failed proof: "forth" or "back" (the latter proves $right = left$)
+	Proof string
+	//Error raised by the proof when Kind is ProofFailed, description of foreign element when Kind is ForeignElement
+	Err error
+	//Argument of proof when Kind is NotStep, expected result when Kind is NotEqual
+	Expected Element
+	//Result of proof
+	Actual Element
+	//Pretty-printed first differing subterm of Expected and Actual when Kind is NotEqual
+	Diff string
+}
+
+func (e *VerifyError) Error() string {
+	switch e.Kind {
+	case ProofFailed:
+		return fmt.Sprintf("'%s' failed: %v", e.Proof, e.Err)
+	case NotStep:
+		return fmt.Sprintf("'%s' is not step", e.Proof)
+	case NotInPresentation:
+		return fmt.Sprintf("'%s' applies relation of other presentation", e.Proof)
+	case ForeignElement:
+		return fmt.Sprintf("'%s' has foreign element: %v", e.Proof, e.Err)
+	}
+	return fmt.Sprintf("'%s' result is not equal to expected element\n%s", e.Proof, e.Diff)
+}
+
+func (e *VerifyError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,118 @@
+package gt
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestTryStepsReturnStepError(t *testing.T) {
+	a, b := NewNamed("a"), NewNamed("b")
+	c := Compose(a, b)
+
+	if _, err := c.TryAssociate(); !isStepError(err, "Associate", c) {
+		t.Fatal("'TryAssociate' does not return StepError:", err)
+	}
+	if _, err := c.TryUnassociate(); !isStepError(err, "Unassociate", c) {
+		t.Fatal("'TryUnassociate' does not return StepError:", err)
+	}
+	if _, err := c.TryAnnihilate(); !isStepError(err, "Annihilate", c) {
+		t.Fatal("'TryAnnihilate' does not return StepError:", err)
+	}
+	if _, err := c.TrySimplify(); !isStepError(err, "Simplify", c) {
+		t.Fatal("'TrySimplify' does not return StepError:", err)
+	}
+	if _, err := AsInversed(c); !isStepError(err, "ToInversed", c) {
+		t.Fatal("'AsInversed' does not return StepError:", err)
+	}
+
+	d, err := Compose(a, Compose(b, a)).TryAssociate()
+	if err != nil || !d.EqualLiteral(Compose(Compose(a, b), a)) {
+		t.Fatal("'TryAssociate' fails on valid argument:", err)
+	}
+}
+
+func isStepError(err error, step string, term Element) bool {
+	var se *StepError
+	return errors.As(err, &se) && se.Step == step && se.Term == term
+}
+
+func TestCheckForthRecoversFromPanic(t *testing.T) {
+	a, b := NewNamed("a"), NewNamed("b")
+
+	err := CheckForth(a, b, func(x Element) Element {
+		return x.ToComposite().Associate()
+	})
+
+	var ve *VerifyError
+	if !errors.As(err, &ve) || ve.Kind != ProofFailed {
+		t.Fatal("'CheckForth' does not report failed proof:", err)
+	}
+	var se *StepError
+	if !errors.As(err, &se) || se.Step != "ToComposite" {
+		t.Fatal("'CheckForth' does not wrap StepError:", err)
+	}
+	if se.Term == nil || !se.Term.EqualLiteral(a) {
+		t.Fatal("StepError of cast has no term:", se.Term)
+	}
+
+	err = CheckForth(Compose(a, b), b, func(x Element) Element {
+		return x.ToComposite().Simplify()
+	})
+	if !errors.As(err, &se) || se.Step != "Simplify" {
+		t.Fatal("'CheckForth' does not wrap StepError:", err)
+	}
+
+	if Verify(a, b, func(x Element) Element { panic("boom") }, nil) {
+		t.Fatal("'Verify' accepts panicking proof")
+	}
+}
+
+func TestCheckFailureKinds(t *testing.T) {
+	a, b := NewNamed("a"), NewNamed("b")
+	id := func(x Element) Element { return x }
+
+	var ve *VerifyError
+	err := CheckForth(a, b, func(x Element) Element { return NewNamed("b") })
+	if !errors.As(err, &ve) || ve.Kind != NotStep {
+		t.Fatal("'CheckForth' does not report NotStep:", err)
+	}
+
+	err = CheckForth(a, b, id)
+	if !errors.As(err, &ve) || ve.Kind != NotEqual {
+		t.Fatal("'CheckForth' does not report NotEqual:", err)
+	}
+
+	err = Check(a, a, id, func(x Element) Element { return NewNamed("a") })
+	if !errors.As(err, &ve) || ve.Kind != NotStep || ve.Proof != "back" {
+		t.Fatal("'Check' does not report failed 'back':", err)
+	}
+
+	if err := Check(a, a, id, id); err != nil {
+		t.Fatal("'Check' rejects identity proof:", err)
+	}
+}
+
+func TestCheckReportsDiff(t *testing.T) {
+	a, b, c := NewNamed("a"), NewNamed("b"), NewNamed("c")
+	id := func(x Element) Element { return x }
+
+	err := CheckForth(Compose(a, Compose(Inverse(b), c)), Compose(a, Compose(c, c)), id)
+
+	var ve *VerifyError
+	if !errors.As(err, &ve) || ve.Kind != NotEqual {
+		t.Fatal("'CheckForth' does not report NotEqual:", err)
+	}
+	if ve.Diff != "at right.left:\n- c\n+ b^-1" {
+		t.Fatalf("wrong diff %q", ve.Diff)
+	}
+	if !ve.Actual.EqualLiteral(Compose(a, Compose(Inverse(b), c))) {
+		t.Fatal("wrong actual element")
+	}
+
+	if d := Diff(Compose(a, b), Compose(a, b)); d != "" {
+		t.Fatalf("diff of equal elements is %q", d)
+	}
+	if d := Diff(Compose(Compose(a, b), c), a); d != "at root:\n- (a*b)*c\n+ a" {
+		t.Fatalf("wrong diff %q", d)
+	}
+}
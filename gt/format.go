@@ -0,0 +1,161 @@
+package gt
+
+import (
+	"fmt"
+	"strings"
+)
+
+//formats element in syntax of Parse with minimal parentheses
+func format(el Element) string {
+	switch c := el.(type) {
+	case *Composite:
+		l := format(c.left)
+		if _, ok := c.left.(*Composite); ok {
+			l = "(" + l + ")"
+		}
+		return l + "*" + format(c.right)
+	case *Inversed:
+		if _, ok := c.operand.(*Composite); ok {
+			return "(" + format(c.operand) + ")^-1"
+		}
+		return format(c.operand) + "^-1"
+	case *Named:
+		return c.name
+	case *Var:
+		return "?" + c.name
+	case *Identity:
+		return "e"
+	}
+	return fmt.Sprintf("%T", el)
+}
+
+//Formats element in syntax of Parse with minimal parentheses, e.g. "(a*b)*c^-1"
+func (c *Composite) String() string { return format(c) }
+
+//Formats element in syntax of Parse with minimal parentheses, e.g. "(a*b)^-1"
+func (c *Inversed) String() string { return format(c) }
+
+//Returns name of element
+func (c *Named) String() string { return format(c) }
+
+//Returns "e"
+func (c *Identity) String() string { return format(c) }
+
+//Returns name of variable prefixed with "?"
+func (c *Var) String() string { return format(c) }
+
+//Notation of composition in LaTeX
+type LaTeXOp int
+
+const (
+	//$a\cdot b$
+	Cdot LaTeXOp = iota
+	//$a\circ b$
+	Circ
+	//$ab$, multi-letter names are separated by thin space: $\mathit{ab}\,c$
+	Juxtaposition
+)
+
+//Options of FormatLaTeX
+type LaTeXOptions struct {
+	//Parenthesize all composites, e.g. $a\cdot (b\cdot c)$ instead of $a\cdot b\cdot c$
+	AllParens bool
+	Op        LaTeXOp
+}
+
+//Formats element in LaTeX (without enclosing "$"). Products without parentheses are right associative as in Parse.
+func FormatLaTeX(el Element, opts LaTeXOptions) string {
+	var b strings.Builder
+	formatLaTeX(&b, el, opts)
+	return b.String()
+}
+
+func formatLaTeX(b *strings.Builder, el Element, opts LaTeXOptions) {
+	switch c := el.(type) {
+	case *Composite:
+		_, lc := c.left.(*Composite)
+		_, rc := c.right.(*Composite)
+		formatLaTeXOperand(b, c.left, lc, opts)
+		switch opts.Op {
+		case Cdot:
+			b.WriteString(`\cdot `)
+		case Circ:
+			b.WriteString(`\circ `)
+		case Juxtaposition:
+			if edgeName(c.left, true, lc, opts) || edgeName(c.right, false, rc && opts.AllParens, opts) {
+				b.WriteString(`\,`)
+			}
+		}
+		formatLaTeXOperand(b, c.right, rc && opts.AllParens, opts)
+	case *Inversed:
+		simple := true
+		switch c.operand.(type) {
+		case *Composite, *Inversed:
+			simple = false
+		}
+		formatLaTeXOperand(b, c.operand, !simple, opts)
+		b.WriteString("^{-1}")
+	case *Named:
+		if len(c.name) > 1 {
+			fmt.Fprintf(b, `\mathit{%s}`, c.name)
+		} else {
+			b.WriteString(c.name)
+		}
+	case *Var:
+		fmt.Fprintf(b, `\mathit{?%s}`, c.name)
+	case *Identity:
+		b.WriteString("e")
+	default:
+		fmt.Fprintf(b, "%T", el)
+	}
+}
+
+//checks whether LaTeX of "el" ends (or starts if not "end") with multi-letter name, so that juxtaposition needs space
+func edgeName(el Element, end, parens bool, opts LaTeXOptions) bool {
+	if parens {
+		return false
+	}
+	switch c := el.(type) {
+	case *Composite:
+		if end {
+			_, rc := c.right.(*Composite)
+			return edgeName(c.right, end, rc && opts.AllParens, opts)
+		}
+		_, lc := c.left.(*Composite)
+		return edgeName(c.left, end, lc, opts)
+	case *Inversed:
+		if end {
+			return false
+		}
+		_, simple := c.operand.(*Named)
+		return simple && edgeName(c.operand, end, false, opts)
+	case *Named:
+		return len(c.name) > 1
+	}
+	return false
+}
+
+func formatLaTeXOperand(b *strings.Builder, el Element, parens bool, opts LaTeXOptions) {
+	if parens {
+		b.WriteString("(")
+	}
+	formatLaTeX(b, el, opts)
+	if parens {
+		b.WriteString(")")
+	}
+}
+
+//Formats element in LaTeX with default options, e.g. $(a\cdot b)\cdot c^{-1}$
+func (c *Composite) LaTeX() string { return FormatLaTeX(c, LaTeXOptions{}) }
+
+//Formats element in LaTeX with default options, e.g. $(a\cdot b)^{-1}$
+func (c *Inversed) LaTeX() string { return FormatLaTeX(c, LaTeXOptions{}) }
+
+//Formats element in LaTeX with default options
+func (c *Named) LaTeX() string { return FormatLaTeX(c, LaTeXOptions{}) }
+
+//Formats element in LaTeX with default options
+func (c *Identity) LaTeX() string { return FormatLaTeX(c, LaTeXOptions{}) }
+
+//Formats element in LaTeX with default options
+func (c *Var) LaTeX() string { return FormatLaTeX(c, LaTeXOptions{}) }
@@ -0,0 +1,52 @@
+package gt
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestString(t *testing.T) {
+	tests := []string{
+		"a",
+		"e",
+		"a*b*c",
+		"(a*b)*c",
+		"(a*b)^-1",
+		"a^-1^-1",
+		"(a*(b*c)^-1)*e^-1",
+		"?x*?y^-1",
+	}
+	for _, src := range tests {
+		el := MustParse(src)
+		if s := fmt.Sprint(el); s != src {
+			t.Fatalf("%q is formatted as %q", src, s)
+		}
+	}
+}
+
+func TestLaTeX(t *testing.T) {
+	d := MustParse("a*(b*(b^-1*c))")
+
+	if s := d.(*Composite).LaTeX(); s != `a\cdot b\cdot b^{-1}\cdot c` {
+		t.Fatal("wrong LaTeX:", s)
+	}
+	if s := FormatLaTeX(d, LaTeXOptions{AllParens: true}); s != `a\cdot (b\cdot (b^{-1}\cdot c))` {
+		t.Fatal("wrong LaTeX with all parentheses:", s)
+	}
+	if s := FormatLaTeX(MustParse("(a*b)*c"), LaTeXOptions{Op: Circ}); s != `(a\circ b)\circ c` {
+		t.Fatal("wrong LaTeX with circ:", s)
+	}
+
+	el := MustParse("(a*b)^-1*a^-1^-1")
+	if s := FormatLaTeX(el, LaTeXOptions{Op: Juxtaposition}); s != `(ab)^{-1}(a^{-1})^{-1}` {
+		t.Fatal("wrong LaTeX with juxtaposition:", s)
+	}
+
+	if s := MustParse("?x*x").(*Composite).LaTeX(); s != `\mathit{?x}\cdot x` {
+		t.Fatal("variable is not marked in LaTeX:", s)
+	}
+	el = MustParse("ab*c*ab^-1*(a*b)")
+	if s := FormatLaTeX(el, LaTeXOptions{Op: Juxtaposition}); s != `\mathit{ab}\,c\,\mathit{ab}^{-1}ab` {
+		t.Fatal("multi-letter names are not separated in juxtaposition:", s)
+	}
+}
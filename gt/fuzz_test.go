@@ -0,0 +1,197 @@
+package gt
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+//Reads fuzz input byte by byte, zeros when it is over
+type fuzzReader struct {
+	data []byte
+}
+
+func (r *fuzzReader) next() int {
+	if len(r.data) == 0 {
+		return 0
+	}
+	b := r.data[0]
+	r.data = r.data[1:]
+	return int(b)
+}
+
+//reads term: a, b, e, inversion or composite
+func (r *fuzzReader) term(depth int) Element {
+	b := r.next()
+	if depth == 0 {
+		b %= 3
+	}
+	switch b % 5 {
+	case 0:
+		return NewNamed("a")
+	case 1:
+		return NewNamed("b")
+	case 2:
+		return NewIdentity()
+	case 3:
+		return Inverse(r.term(depth - 1))
+	}
+	return Compose(r.term(depth-1), r.term(depth-1))
+}
+
+func (r *fuzzReader) path() Path {
+	p := make(Path, r.next()%4)
+	for i := range p {
+		p[i] = Move(r.next() % 3)
+	}
+	return p
+}
+
+func (r *fuzzReader) rule() func(Element) Element {
+	switch r.next() % 10 {
+	case 0:
+		return Associator
+	case 1:
+		return Unassociator
+	case 2:
+		return Annihilator
+	case 3:
+		return Simplifier
+	case 4:
+		return Unsimplifier(r.next()%2 == 0)
+	case 5:
+		return Unannihilator(r.term(2), r.next()%2 == 0)
+	case 6:
+		return DoubleInverter
+	case 7:
+		return UndoubleInverter
+	case 8:
+		return Distributor
+	}
+	return Undistributor
+}
+
+//Maximum size of term made by proof, it may double at each call otherwise
+const maxFuzzSize = 64
+
+//returns number of subterms of element
+func size(el Element) int {
+	switch c := el.(type) {
+	case *Composite:
+		return 1 + size(c.left) + size(c.right)
+	case *Inversed:
+		return 1 + size(c.operand)
+	}
+	return 1
+}
+
+//Foreign element embedding element of this package: it is literally equal to anything and clones to "clone"
+type fuzzForeign struct {
+	Element
+	clone Element
+}
+
+func (f *fuzzForeign) EqualLiteral(Element) bool { return true }
+func (f *fuzzForeign) CloneLiteral() Element     { return f.clone }
+
+//Proof read from fuzz input: sequence of public calls mixing steps with accessors, clones, constructors, closures
+//of Map, decoding JSON into elements, foreign elements and elements leaked from earlier calls
+type fuzzProof struct {
+	data []byte
+	pool []Element
+}
+
+func (p *fuzzProof) run(el Element) Element {
+	r := &fuzzReader{data: p.data}
+	pool := append(append([]Element(nil), p.pool...), el)
+	pick := func() Element { return pool[r.next()%len(pool)] }
+	foreign := func(el Element) Element { return &fuzzForeign{Element: el, clone: r.term(2)} }
+	closure := func() func(Element) Element {
+		switch r.next() % 6 {
+		case 0:
+			return func(el Element) Element { return el }
+		case 1:
+			return func(el Element) Element { return el.CloneLiteral() }
+		case 2:
+			return func(el Element) Element {
+				pool = append(pool, el)
+				return el
+			}
+		case 3:
+			return func(Element) Element { return pick() }
+		case 5:
+			return foreign
+		}
+		return At(r.path(), r.rule())
+	}
+	for n := r.next() % 16; n > 0; n-- {
+		switch r.next() % 10 {
+		case 0:
+			el = RewriteAt(el, r.path(), r.rule())
+		case 1:
+			el = el.ToComposite().Left()
+		case 2:
+			el = el.ToComposite().Right()
+		case 3:
+			el = el.CloneLiteral()
+		case 4:
+			el = Compose(el, pick())
+		case 5:
+			el = Inverse(el)
+		case 6:
+			if c, ok := el.(*Inversed); ok {
+				el = c.Map(closure())
+			} else {
+				el = el.ToComposite().Map(closure(), closure())
+			}
+		case 7:
+			el = pick()
+		case 8:
+			if u, ok := el.(json.Unmarshaler); ok {
+				if data, err := json.Marshal(pick()); err == nil {
+					u.UnmarshalJSON(data)
+				}
+			}
+		case 9:
+			el = foreign(el)
+		}
+		if size(el) > maxFuzzSize {
+			panic("term is too big")
+		}
+		pool = append(pool, el)
+	}
+	return el
+}
+
+//Fuzz input is left and right terms followed by proof. Besides given right term the proof is verified against its
+//own result, so that accepted proofs are common. VerifyForth must reject every pair not equal in free group.
+func FuzzVerify(f *testing.F) {
+	//a*a^-1 = e by Annihilate
+	f.Add([]byte{4, 0, 3, 0, 2, 1, 0, 0, 2})
+	//a*(b*a) = (a*b)*a by Associate
+	f.Add([]byte{4, 0, 4, 1, 0, 4, 4, 0, 1, 0, 1, 0, 0, 0})
+	//returning right term
+	f.Add([]byte{0, 1, 1, 7, 0})
+	//a*b = a*(b*e) by Map with leaking closure and Unsimplify at right
+	f.Add([]byte{4, 0, 1, 4, 0, 4, 1, 2, 1, 6, 2, 4, 0, 4, 1})
+	//b^-1 = e by Map of operand returning right term
+	f.Add([]byte{3, 1, 2, 1, 6, 3, 0})
+	//a = b by decoding right term into argument
+	f.Add([]byte{0, 1, 1, 8, 0})
+	//a*b = a*a by Map with foreign element cloned to a
+	f.Add([]byte{4, 0, 1, 4, 0, 0, 1, 6, 0, 5, 0})
+	f.Fuzz(func(t *testing.T, data []byte) {
+		r := &fuzzReader{data: data}
+		left, right := r.term(3), r.term(3)
+		proof := &fuzzProof{data: r.data, pool: []Element{right}}
+		targets := []Element{right}
+		if res, err := run(proof.run, left.CloneLiteral()); err == nil {
+			targets = append(targets, res.CloneLiteral())
+		}
+		for _, target := range targets {
+			proof.pool[0] = target
+			if VerifyForth(left, target, proof.run) && !Equal(left, target) {
+				t.Fatalf("proof %v that %v = %v is accepted", proof.data, left, target)
+			}
+		}
+	})
+}
@@ -1,21 +1,10 @@
 package gt
 
 import (
+	"errors"
 	"fmt"
-	"sync"
 )
 
-var lastToken = 1
-var mut = &sync.Mutex{}
-
-func tok() int {
-	mut.Lock()
-	token := lastToken
-	lastToken++
-	mut.Unlock()
-	return token
-}
-
 //Grooup element interface:
 type Element interface {
 	//Checks whether two elements are equal literally (although Same() may return false)
@@ -29,33 +18,77 @@ type Element interface {
 	ToInversed() *Inversed
 	ToNamed() *Named
 	ToIdentity() *Identity
+	ToVar() *Var
 
-	setToken(int)
-	token() int
+	token() *token
 	same(Element) bool
+	history() []ProofStep
+	adopt(*token, []ProofStep)
 }
 
 //Element prototype:
 type element struct {
-	tok int
+	tok  *token
+	hist []ProofStep
+	//element has got token and history of proof
+	adopted bool
 }
 
-func (el *element) token() int {
+func (el *element) token() *token {
 	return el.tok
 }
 
 func (el *element) init() {
-	el.tok = tok()
+	el.tok = &token{}
+}
+
+//steps of proof made to obtain element, with paths relative to element
+func (el *element) history() []ProofStep {
+	return el.hist
 }
 
-func (el *element) setToken(t int) {
-	el.tok = t
+//Makes new element a node of proof with token "t" and history "h". Elements are immutable once they are made by a
+//step, so each step makes new node and adopt panics if element is adopted twice.
+func (el *element) adopt(t *token, h []ProofStep) {
+	if el.adopted {
+		panic("gt: element is modified after construction")
+	}
+	el.adopted = true
+	el.tok, el.hist = t, h
+}
+
+//makes "n" the result of step "s" applied to "from"
+func derive(n, from Element, s Step) {
+	step := ProofStep{Step: s, Before: from.CloneLiteral(), After: n.CloneLiteral()}
+	n.adopt(from.token(), appendSteps(from.history(), step))
+	from.token().ctx.record(from, n, []ProofStep{step})
 }
 
-func (el *element) ToComposite() *Composite { panic("It's not Composite") }
-func (el *element) ToInversed() *Inversed   { panic("It's not Inversed") }
-func (el *element) ToNamed() *Named         { panic("It's not Named") }
-func (el *element) ToIdentity() *Identity   { panic("It's not Identity") }
+//casts to other types panic with *StepError holding the element
+func (c *Composite) ToInversed() *Inversed { panic(castError("Inversed", c)) }
+func (c *Composite) ToNamed() *Named       { panic(castError("Named", c)) }
+func (c *Composite) ToIdentity() *Identity { panic(castError("Identity", c)) }
+func (c *Composite) ToVar() *Var           { panic(castError("Var", c)) }
+
+func (c *Inversed) ToComposite() *Composite { panic(castError("Composite", c)) }
+func (c *Inversed) ToNamed() *Named         { panic(castError("Named", c)) }
+func (c *Inversed) ToIdentity() *Identity   { panic(castError("Identity", c)) }
+func (c *Inversed) ToVar() *Var             { panic(castError("Var", c)) }
+
+func (c *Named) ToComposite() *Composite { panic(castError("Composite", c)) }
+func (c *Named) ToInversed() *Inversed   { panic(castError("Inversed", c)) }
+func (c *Named) ToIdentity() *Identity   { panic(castError("Identity", c)) }
+func (c *Named) ToVar() *Var             { panic(castError("Var", c)) }
+
+func (c *Identity) ToComposite() *Composite { panic(castError("Composite", c)) }
+func (c *Identity) ToInversed() *Inversed   { panic(castError("Inversed", c)) }
+func (c *Identity) ToNamed() *Named         { panic(castError("Named", c)) }
+func (c *Identity) ToVar() *Var             { panic(castError("Var", c)) }
+
+func (c *Var) ToComposite() *Composite { panic(castError("Composite", c)) }
+func (c *Var) ToInversed() *Inversed   { panic(castError("Inversed", c)) }
+func (c *Var) ToNamed() *Named         { panic(castError("Named", c)) }
+func (c *Var) ToIdentity() *Identity   { panic(castError("Identity", c)) }
 
 //Checks whether one of two elements was made from other during the proof
 func (el *element) Same(other Element) bool {
@@ -68,35 +101,129 @@ func (el *element) same(other Element) bool {
 
 //Verify proof (forth, back) that $left = right$
 func Verify(left, right Element, forth, back func(Element) Element) bool {
-	l := left.CloneLiteral()
-	r := right.CloneLiteral()
+	return Check(left, right, forth, back) == nil
+}
 
-	lr := forth(l)
-	rl := back(r)
+//Verify proof "forth" that $left = right$
+func VerifyForth(left, right Element, forth func(Element) Element) bool {
+	return CheckForth(left, right, forth) == nil
+}
 
-	backIsStep := r.same(rl)
-	forthIsStep := l.same(lr)
+//Same as Verify but reports why the proof failed. Returns nil or *VerifyError.
+func Check(left, right Element, forth, back func(Element) Element) error {
+	if err := CheckForth(left, right, forth); err != nil {
+		return err
+	}
+	_, err := check(nil, right, left, back, "back", nil)
+	return err
+}
 
-	return forthIsStep && backIsStep && lr.EqualLiteral(r) && rl.EqualLiteral(l)
+//Same as VerifyForth but reports why the proof failed. Returns nil or *VerifyError.
+func CheckForth(left, right Element, forth func(Element) Element) error {
+	_, err := check(nil, left, right, forth, "forth", nil)
+	return err
 }
 
-func VerifyForth(left, right Element, forth func(Element) Element) bool {
+//runs proof on clone of "left" owned by "ctx" (new context if nil) and returns its steps if it is verified in
+//presentation "pres" (nil for free group)
+func check(ctx *ProofContext, left, right Element, proof func(Element) Element, name string, pres *Presentation) ([]ProofStep, error) {
+	for _, el := range []Element{left, right} {
+		if err := sealed(el); err != nil {
+			return nil, &VerifyError{Kind: ForeignElement, Proof: name, Err: err}
+		}
+	}
+	if ctx == nil {
+		ctx = NewProofContext()
+	}
 	l := left.CloneLiteral()
+	l.adopt(&token{ctx: ctx}, nil)
 	r := right.CloneLiteral()
 
-	lr := forth(l)
-	forthIsStep := l.same(lr)
-
-	if !forthIsStep {
-		fmt.Println("VerifyForth: 'forth' is not step")
+	lr, err := run(proof, l)
+	if fe := (*foreignError)(nil); errors.As(err, &fe) {
+		return nil, &VerifyError{Kind: ForeignElement, Proof: name, Err: err}
+	}
+	if err != nil {
+		return nil, &VerifyError{Kind: ProofFailed, Proof: name, Err: err}
+	}
+	if err := sealed(lr); err != nil {
+		return nil, &VerifyError{Kind: ForeignElement, Proof: name, Err: err}
+	}
+	if !l.same(lr) {
+		return nil, &VerifyError{Kind: NotStep, Proof: name, Expected: l, Actual: lr}
+	}
+	steps, ok := ctx.provenance(l, lr)
+	if !ok {
+		return nil, &VerifyError{Kind: NotStep, Proof: name, Expected: l, Actual: lr}
 	}
+	for _, s := range steps {
+		for _, el := range []Element{s.Arg, s.Before, s.After} {
+			if el == nil {
+				continue
+			}
+			if err := sealed(el); err != nil {
+				return nil, &VerifyError{Kind: ForeignElement, Proof: name, Err: err}
+			}
+		}
+		//presentation is unexported, so it is trusted rather than the name of step
+		if (s.Rule == RuleRelation || s.pres != nil) && s.pres != pres {
+			return nil, &VerifyError{Kind: NotInPresentation, Proof: name, Expected: l, Actual: lr}
+		}
+	}
+	if !lr.EqualLiteral(r) {
+		return nil, &VerifyError{Kind: NotEqual, Proof: name, Expected: r, Actual: lr, Diff: Diff(r, lr)}
+	}
+	return steps, nil
+}
+
+//Element of type which is not of this package
+type foreignError struct {
+	el Element
+}
+
+func (e *foreignError) Error() string {
+	return fmt.Sprintf("element of type %T is not made by package gt", e.el)
+}
 
-	lrEq := lr.EqualLiteral(r)
+//panics with error of sealed: steps never take foreign elements, since their methods may lie to the step
+func mustSeal(el Element) {
+	if err := sealed(el); err != nil {
+		panic(err)
+	}
+}
 
-	if !lrEq {
-		fmt.Println("VerifyForth: 'forth(left) != right'")
+//checks that element and all its subterms are of this package's types, so no foreign code runs on verification
+func sealed(el Element) error {
+	switch c := el.(type) {
+	case *Composite:
+		if err := sealed(c.left); err != nil {
+			return err
+		}
+		return sealed(c.right)
+	case *Inversed:
+		return sealed(c.operand)
+	case *Named, *Identity, *Var:
+		return nil
+	}
+	return &foreignError{el: el}
+}
+
+//runs proof recovering from panics of failed steps
+func run(proof func(Element) Element, el Element) (res Element, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			if e, ok := r.(error); ok {
+				err = e
+			} else {
+				err = fmt.Errorf("%v", r)
+			}
+		}
+	}()
+	res = proof(el)
+	if res == nil {
+		return nil, errors.New("proof returned nil")
 	}
-	return forthIsStep && lr.EqualLiteral(r)
+	return res, nil
 }
 
 //Composite element of group:
@@ -118,6 +245,14 @@ func Compose(a, b Element) *Composite {
 	return c
 }
 
+//composes elements without cloning them, so terms share immutable subterms. Only for elements of this package's
+//types that are not made by proof.
+func compose(a, b Element) *Composite {
+	c := &Composite{left: a, right: b}
+	c.init()
+	return c
+}
+
 //Checks whether two elements are equal literally (although same() may return false)
 func (c *Composite) EqualLiteral(other Element) bool {
 	if o, ok := other.(*Composite); ok {
@@ -128,68 +263,101 @@ func (c *Composite) EqualLiteral(other Element) bool {
 
 //Makes literal clone of element (although same() will return false)
 func (c *Composite) CloneLiteral() Element {
-	return Compose(c.left.CloneLiteral(), c.right.CloneLiteral())
+	return Compose(c.left, c.right)
 }
 
 //turns $a\cdot (b\cdot c)$ to $(a\cdot b)\cdot c$. This is a step of proof.
 func (c *Composite) Associate() *Composite {
+	n, err := c.TryAssociate()
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+//Same as Associate but returns *StepError instead of panicking
+func (c *Composite) TryAssociate() (*Composite, error) {
 	if r, ok := c.right.(*Composite); ok {
 		n := Compose(Compose(c.left, r.left), r.right)
-		n.setToken(c.token())
-		return n
+		derive(n, c, Step{Rule: RuleAssociate})
+		return n, nil
 	}
-	panic("Associator requires $a\\cdot (b\\cdot c)$ type arguments")
+	return nil, &StepError{Step: "Associate", Pattern: `$a\cdot (b\cdot c)$`, Term: c}
 }
 
 //turns $(a\cdot b)\cdot c$ to $a\cdot (b\cdot c)$. This is a step of proof.
 func (c *Composite) Unassociate() *Composite {
+	n, err := c.TryUnassociate()
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+//Same as Unassociate but returns *StepError instead of panicking
+func (c *Composite) TryUnassociate() (*Composite, error) {
 	if l, ok := c.left.(*Composite); ok {
 		n := Compose(l.left, Compose(l.right, c.right))
-		n.setToken(c.token())
-		return n
+		derive(n, c, Step{Rule: RuleUnassociate})
+		return n, nil
 	}
-	panic("Unassociator requires $a\\cdot (b\\cdot c)$ type arguments")
+	return nil, &StepError{Step: "Unassociate", Pattern: `$(a\cdot b)\cdot c$`, Term: c}
 }
 
 //turns $a\cdot a^{-1}$ and $a^{-1}\cdot a$ to $e$. This is a step of proof.
 func (c *Composite) Annihilate() *Identity {
-	n := NewIdentity()
-	n.setToken(c.token())
-	if r, ok := c.right.(*Inversed); ok {
-		if r.operand.EqualLiteral(c.left) {
-			return n
-		}
-	} else if l, ok := c.left.(*Inversed); ok {
-		if l.operand.EqualLiteral(c.right) {
-			return n
-		}
+	n, err := c.TryAnnihilate()
+	if err != nil {
+		panic(err)
 	}
-	panic("Annihilator requires $a\\cdot (a^{-1})$ type arguments")
+	return n
+}
+
+//Same as Annihilate but returns *StepError instead of panicking
+func (c *Composite) TryAnnihilate() (*Identity, error) {
+	r, rok := c.right.(*Inversed)
+	l, lok := c.left.(*Inversed)
+	if rok && r.operand.EqualLiteral(c.left) || lok && l.operand.EqualLiteral(c.right) {
+		n := NewIdentity()
+		derive(n, c, Step{Rule: RuleAnnihilate})
+		return n, nil
+	}
+	return nil, &StepError{Step: "Annihilate", Pattern: `$a\cdot a^{-1}$ or $a^{-1}\cdot a$`, Term: c}
 }
 
 //turns $a\cdot e$ and $e\cdot a$ to $a$. This is a step of proof.
 func (c *Composite) Simplify() Element {
+	n, err := c.TrySimplify()
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+//Same as Simplify but returns *StepError instead of panicking
+func (c *Composite) TrySimplify() (Element, error) {
 	if _, ok := c.right.(*Identity); ok {
-		n := c.left
-		n.setToken(c.token())
-		return n
+		n := c.left.CloneLiteral()
+		derive(n, c, Step{Rule: RuleSimplify})
+		return n, nil
 	} else if _, ok := c.left.(*Identity); ok {
-		n := c.right
-		n.setToken(c.token())
-		return n
+		n := c.right.CloneLiteral()
+		derive(n, c, Step{Rule: RuleSimplify})
+		return n, nil
 	}
-	panic("Simplificator requires $a\\cdot e$ or  $e\\cdot a$ type arguments")
+	return nil, &StepError{Step: "Simplify", Pattern: `$a\cdot e$ or $e\cdot a$`, Term: c}
 }
 
 //turns $a$ and $e\cdot a$ or $a\cdot e$ depending on "left". This is a step of proof.
 func Unsimplify(el Element, left bool) *Composite {
+	mustSeal(el)
 	if left {
 		n := Compose(NewIdentity(), el)
-		n.setToken(el.token())
+		derive(n, el, Step{Rule: RuleUnsimplify, Left: left})
 		return n
 	} else {
 		n := Compose(el, NewIdentity())
-		n.setToken(el.token())
+		derive(n, el, Step{Rule: RuleUnsimplify, Left: left})
 		return n
 	}
 }
@@ -197,10 +365,14 @@ func Unsimplify(el Element, left bool) *Composite {
 //maps proofs to left and right elements of composite. This is a step of proof iff both "left" and "right" are steps.
 func (c *Composite) Map(left func(Element) Element, right func(Element) Element) *Composite {
 	l := left(c.left)
+	mustSeal(l)
 	r := right(c.right)
+	mustSeal(r)
 	n := Compose(l, r)
 	if l.same(c.left) && r.same(c.right) {
-		n.setToken(c.token())
+		steps := appendSteps(under(l.history(), MoveLeft), under(r.history(), MoveRight)...)
+		n.adopt(c.token(), appendSteps(c.history(), steps...))
+		c.token().ctx.record(c, n, steps)
 	}
 	return n
 }
@@ -226,12 +398,19 @@ func (c *Inversed) ToInversed() *Inversed { return c }
 //Inverses element of group:
 func Inverse(el Element) *Inversed {
 	n := &Inversed{
-		operand: el,
+		operand: el.CloneLiteral(),
 	}
 	n.init()
 	return n
 }
 
+//inverses element without cloning it (see compose)
+func inverse(el Element) *Inversed {
+	n := &Inversed{operand: el}
+	n.init()
+	return n
+}
+
 //Checks whether two elements are equal literally (although same() may return false)
 func (c *Inversed) EqualLiteral(other Element) bool {
 	if o, ok := other.(*Inversed); ok {
@@ -242,15 +421,23 @@ func (c *Inversed) EqualLiteral(other Element) bool {
 
 //Makes literal clone of element (although same() will return false)
 func (c *Inversed) CloneLiteral() Element {
-	return Inverse(c.operand.CloneLiteral())
+	return Inverse(c.operand)
+}
+
+//returns operand of inversion
+func (c *Inversed) Operand() Element {
+	return c.operand.CloneLiteral()
 }
 
 //maps proofs to operand of inversion. This is a step of proof iff "f" is a step.
 func (c *Inversed) Map(f func(Element) Element) *Inversed {
 	op := f(c.operand)
+	mustSeal(op)
 	n := Inverse(op)
 	if op.same(c.operand) {
-		n.setToken(c.token())
+		steps := under(op.history(), MoveOperand)
+		n.adopt(c.token(), appendSteps(c.history(), steps...))
+		c.token().ctx.record(c, n, steps)
 	}
 	return n
 }
@@ -317,13 +504,14 @@ func (c *Identity) CloneLiteral() Element {
 
 //Turns $e$ to $a\cdot a^{-1}$ or to $a^{-1}\cdot a$ dependinf on "left". This is a step of proof.
 func (c *Identity) Unannihilate(el Element, left bool) *Composite {
+	mustSeal(el)
 	if left {
 		n := Compose(Inverse(el), el)
-		n.setToken(c.token())
+		derive(n, c, Step{Rule: RuleUnannihilate, Arg: el.CloneLiteral(), Left: left})
 		return n
 	} else {
 		n := Compose(el, Inverse(el))
-		n.setToken(c.token())
+		derive(n, c, Step{Rule: RuleUnannihilate, Arg: el.CloneLiteral(), Left: left})
 		return n
 	}
 }
@@ -1,6 +1,7 @@
 package gt
 
 import (
+	"errors"
 	"testing"
 )
 
@@ -203,3 +204,59 @@ func TestIdentityCloneLiteralIsNotStep(t *testing.T) {
 		t.Fatal("'Identity.CloneLiteral' is a step")
 	}
 }
+
+//foreign element which survives cloning and is literally equal to anything
+type mimic struct {
+	*Named
+}
+
+func (m mimic) EqualLiteral(Element) bool { return true }
+func (m mimic) CloneLiteral() Element     { return m }
+
+//foreign element which clones to other element
+type impostor struct {
+	*Named
+}
+
+func (m *impostor) CloneLiteral() Element { return NewNamed("c") }
+
+func TestVerifyRejectsForeignElements(t *testing.T) {
+	a, b := NewNamed("a"), NewNamed("b")
+	m := mimic{NewNamed("b")}
+	id := func(x Element) Element { return x }
+
+	//$a = a\cdot e = a\cdot (m^{-1}\cdot m) = (a\cdot m^{-1})\cdot m = e\cdot m = m$
+	proof := func(x Element) Element {
+		y := Unsimplify(x, false).Map(id, func(el Element) Element {
+			return el.ToIdentity().Unannihilate(m, true)
+		}).Associate()
+		return y.Map(Annihilator, id).Simplify()
+	}
+	tests := []struct {
+		left, right Element
+		proof       func(Element) Element
+	}{
+		{m, b, id},
+		{a, Compose(a, m), id},
+		{Inverse(Compose(m, a)), a, id},
+		{a, b, proof},
+		{a, b, func(Element) Element { return m }},
+		//lie turns into elements of this package when Map clones result of closure
+		{Compose(a, b), Compose(a, NewNamed("c")), func(x Element) Element {
+			return x.ToComposite().Map(id, func(el Element) Element { return &impostor{el.ToNamed()} })
+		}},
+		{Inverse(b), Inverse(NewNamed("c")), func(x Element) Element {
+			return x.ToInversed().Map(func(el Element) Element { return &impostor{el.ToNamed()} })
+		}},
+		{b, Compose(NewNamed("c"), NewIdentity()), func(x Element) Element {
+			return Unsimplify(&impostor{x.ToNamed()}, false)
+		}},
+	}
+	for i, tt := range tests {
+		err := CheckForth(tt.left, tt.right, tt.proof)
+		var ve *VerifyError
+		if !errors.As(err, &ve) || ve.Kind != ForeignElement {
+			t.Fatalf("test %d: foreign element is not rejected: %v", i, err)
+		}
+	}
+}
@@ -0,0 +1,321 @@
+package gt
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+//JSON operations of elements
+const (
+	jsonCompose  = "compose"
+	jsonInverse  = "inverse"
+	jsonNamed    = "named"
+	jsonIdentity = "identity"
+	jsonVar      = "var"
+)
+
+//encoded element: {"op":"compose","left":…,"right":…}, {"op":"inverse","operand":…}, {"op":"named","name":…},
+//{"op":"var","name":…} or {"op":"identity"}
+type jsonElement struct {
+	Op      string  `json:"op"`
+	Left    Element `json:"left,omitempty"`
+	Right   Element `json:"right,omitempty"`
+	Operand Element `json:"operand,omitempty"`
+	Name    *string `json:"name,omitempty"`
+}
+
+type rawElement struct {
+	Op      string          `json:"op"`
+	Left    json.RawMessage `json:"left"`
+	Right   json.RawMessage `json:"right"`
+	Operand json.RawMessage `json:"operand"`
+	Name    *string         `json:"name"`
+}
+
+func (c *Composite) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonElement{Op: jsonCompose, Left: c.left, Right: c.right})
+}
+
+func (c *Inversed) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonElement{Op: jsonInverse, Operand: c.operand})
+}
+
+func (c *Named) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonElement{Op: jsonNamed, Name: &c.name})
+}
+
+func (c *Identity) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonElement{Op: jsonIdentity})
+}
+
+func (c *Var) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonElement{Op: jsonVar, Name: &c.name})
+}
+
+//Decodes element encoded by MarshalJSON. Decoded element is never same() with any other.
+func UnmarshalElement(data []byte) (Element, error) {
+	var raw rawElement
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	switch raw.Op {
+	case jsonCompose:
+		l, err := unmarshalOperand(raw.Left, raw.Op, "left")
+		if err != nil {
+			return nil, err
+		}
+		r, err := unmarshalOperand(raw.Right, raw.Op, "right")
+		if err != nil {
+			return nil, err
+		}
+		return Compose(l, r), nil
+	case jsonInverse:
+		op, err := unmarshalOperand(raw.Operand, raw.Op, "operand")
+		if err != nil {
+			return nil, err
+		}
+		return Inverse(op), nil
+	case jsonNamed, jsonVar:
+		if raw.Name == nil {
+			return nil, fmt.Errorf("gt: %q element requires \"name\"", raw.Op)
+		}
+		if raw.Op == jsonVar {
+			return NewVar(*raw.Name), nil
+		}
+		return NewNamed(*raw.Name), nil
+	case jsonIdentity:
+		return NewIdentity(), nil
+	}
+	return nil, fmt.Errorf("gt: unknown element op %q", raw.Op)
+}
+
+func unmarshalOperand(data json.RawMessage, op, field string) (Element, error) {
+	if len(data) == 0 || string(data) == "null" {
+		return nil, fmt.Errorf("gt: %q element requires %q", op, field)
+	}
+	return UnmarshalElement(data)
+}
+
+func decodeError(el, dst Element) error {
+	return fmt.Errorf("gt: can't decode %s into %T", format(el), dst)
+}
+
+//checks that element is zero value: elements made by constructors or steps are immutable, otherwise proof could
+//decode its argument into anything
+func (el *element) decodable(dst Element) error {
+	if el.tok != nil {
+		return fmt.Errorf("gt: can't decode into constructed %T", dst)
+	}
+	return nil
+}
+
+func (c *Composite) UnmarshalJSON(data []byte) error {
+	if err := c.decodable(c); err != nil {
+		return err
+	}
+	el, err := UnmarshalElement(data)
+	if err != nil {
+		return err
+	}
+	n, ok := el.(*Composite)
+	if !ok {
+		return decodeError(el, c)
+	}
+	*c = *n
+	return nil
+}
+
+func (c *Inversed) UnmarshalJSON(data []byte) error {
+	if err := c.decodable(c); err != nil {
+		return err
+	}
+	el, err := UnmarshalElement(data)
+	if err != nil {
+		return err
+	}
+	n, ok := el.(*Inversed)
+	if !ok {
+		return decodeError(el, c)
+	}
+	*c = *n
+	return nil
+}
+
+func (c *Named) UnmarshalJSON(data []byte) error {
+	if err := c.decodable(c); err != nil {
+		return err
+	}
+	el, err := UnmarshalElement(data)
+	if err != nil {
+		return err
+	}
+	n, ok := el.(*Named)
+	if !ok {
+		return decodeError(el, c)
+	}
+	*c = *n
+	return nil
+}
+
+func (c *Identity) UnmarshalJSON(data []byte) error {
+	if err := c.decodable(c); err != nil {
+		return err
+	}
+	el, err := UnmarshalElement(data)
+	if err != nil {
+		return err
+	}
+	n, ok := el.(*Identity)
+	if !ok {
+		return decodeError(el, c)
+	}
+	*c = *n
+	return nil
+}
+
+func (c *Var) UnmarshalJSON(data []byte) error {
+	if err := c.decodable(c); err != nil {
+		return err
+	}
+	el, err := UnmarshalElement(data)
+	if err != nil {
+		return err
+	}
+	n, ok := el.(*Var)
+	if !ok {
+		return decodeError(el, c)
+	}
+	*c = *n
+	return nil
+}
+
+func (m Move) MarshalText() ([]byte, error) {
+	switch m {
+	case MoveLeft, MoveRight, MoveOperand:
+		return []byte(m.String()), nil
+	}
+	return nil, fmt.Errorf("gt: invalid move %d", int(m))
+}
+
+func (m *Move) UnmarshalText(text []byte) error {
+	for _, v := range []Move{MoveLeft, MoveRight, MoveOperand} {
+		if string(text) == v.String() {
+			*m = v
+			return nil
+		}
+	}
+	return fmt.Errorf("gt: invalid move %q", text)
+}
+
+//encoded step: {"rule":…,"path":["left",…],"arg":…,"left":…,"relator":…,"direction":…,"before":…,"after":…}
+type jsonStep struct {
+	Rule      string    `json:"rule"`
+	Path      Path      `json:"path,omitempty"`
+	Arg       Element   `json:"arg,omitempty"`
+	Left      bool      `json:"left,omitempty"`
+	Relator   int       `json:"relator,omitempty"`
+	Direction Direction `json:"direction,omitempty"`
+	Before    Element   `json:"before,omitempty"`
+	After     Element   `json:"after,omitempty"`
+}
+
+type rawStep struct {
+	Rule      string          `json:"rule"`
+	Path      Path            `json:"path"`
+	Arg       json.RawMessage `json:"arg"`
+	Left      bool            `json:"left"`
+	Relator   int             `json:"relator"`
+	Direction Direction       `json:"direction"`
+	Before    json.RawMessage `json:"before"`
+	After     json.RawMessage `json:"after"`
+}
+
+//decodes optional element
+func unmarshalOptional(data json.RawMessage) (Element, error) {
+	if len(data) == 0 || string(data) == "null" {
+		return nil, nil
+	}
+	return UnmarshalElement(data)
+}
+
+func (s *rawStep) decode() (ProofStep, error) {
+	var err error
+	p := ProofStep{Step: Step{Rule: s.Rule, Path: s.Path, Left: s.Left, Relator: s.Relator, Direction: s.Direction}}
+	if p.Arg, err = unmarshalOptional(s.Arg); err != nil {
+		return p, err
+	}
+	if p.Before, err = unmarshalOptional(s.Before); err != nil {
+		return p, err
+	}
+	p.After, err = unmarshalOptional(s.After)
+	return p, err
+}
+
+func (s Step) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonStep{Rule: s.Rule, Path: s.Path, Arg: s.Arg, Left: s.Left, Relator: s.Relator, Direction: s.Direction})
+}
+
+func (s *Step) UnmarshalJSON(data []byte) error {
+	var raw rawStep
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	raw.Before, raw.After = nil, nil
+	p, err := raw.decode()
+	if err != nil {
+		return err
+	}
+	*s = p.Step
+	return nil
+}
+
+func (s ProofStep) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonStep{Rule: s.Rule, Path: s.Path, Arg: s.Arg, Left: s.Left, Relator: s.Relator, Direction: s.Direction, Before: s.Before, After: s.After})
+}
+
+func (s *ProofStep) UnmarshalJSON(data []byte) error {
+	var raw rawStep
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	p, err := raw.decode()
+	if err != nil {
+		return err
+	}
+	*s = p
+	return nil
+}
+
+//encoded proof: {"left":…,"right":…,"steps":[…]}
+type jsonProof struct {
+	Left  Element     `json:"left"`
+	Right Element     `json:"right"`
+	Steps []ProofStep `json:"steps"`
+}
+
+type rawProof struct {
+	Left  json.RawMessage `json:"left"`
+	Right json.RawMessage `json:"right"`
+	Steps []ProofStep     `json:"steps"`
+}
+
+func (p *Proof) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonProof{Left: p.Left, Right: p.Right, Steps: p.Steps})
+}
+
+func (p *Proof) UnmarshalJSON(data []byte) error {
+	var raw rawProof
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	l, err := unmarshalOperand(raw.Left, "proof", "left")
+	if err != nil {
+		return err
+	}
+	r, err := unmarshalOperand(raw.Right, "proof", "right")
+	if err != nil {
+		return err
+	}
+	*p = Proof{Left: l, Right: r, Steps: raw.Steps}
+	return nil
+}
@@ -0,0 +1,154 @@
+package gt
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestElementJSON(t *testing.T) {
+	d := MustParse("a*(b*(?x^-1*c))*e")
+
+	data, err := json.Marshal(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	el, err := UnmarshalElement(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !el.EqualLiteral(d) {
+		t.Fatal("decoded element is not equal to encoded one:", el)
+	}
+	if el.Same(d) {
+		t.Fatal("decoded element is same with encoded one")
+	}
+
+	var c Composite
+	if err := json.Unmarshal(data, &c); err != nil {
+		t.Fatal(err)
+	}
+	if !c.EqualLiteral(d) || c.Same(d) || c.Same(el) {
+		t.Fatal("wrong decoded Composite:", &c)
+	}
+
+	var n Named
+	if err := json.Unmarshal(data, &n); err == nil {
+		t.Fatal("Composite is decoded into Named")
+	}
+
+	data, err = json.Marshal(Inverse(NewNamed("a")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"op":"inverse","operand":{"op":"named","name":"a"}}` {
+		t.Fatal("wrong encoding:", string(data))
+	}
+}
+
+func TestInverseOfDecodedProof(t *testing.T) {
+	left, right := MustParse("(a*a^-1)*(b*e)"), MustParse("b")
+	p, err := Prove(left, right)
+	if err != nil {
+		t.Fatal(err)
+	}
+	//steps without subterms before and after them
+	data, err := json.Marshal(struct {
+		Left  Element `json:"left"`
+		Right Element `json:"right"`
+		Steps []Step  `json:"steps"`
+	}{p.Left, p.Right, p.Derivation()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var q Proof
+	if err := json.Unmarshal(data, &q); err != nil {
+		t.Fatal(err)
+	}
+	if err := Check(left, right, q.Forth(), q.Inverse().Forth()); err != nil {
+		t.Fatal(err)
+	}
+
+	//relation can't be replayed without presentation
+	src := `{"left":{"op":"named","name":"a"},"right":{"op":"identity"},"steps":[{"rule":"Relation"}]}`
+	if err := json.Unmarshal([]byte(src), &q); err != nil {
+		t.Fatal(err)
+	}
+	var re *ReplayError
+	if _, err := q.TryInverse(); !errors.As(err, &re) {
+		t.Fatal("proof with relation is inverted:", err)
+	}
+}
+
+func TestUnmarshalJSONIntoConstructedElement(t *testing.T) {
+	a, b := NewNamed("a"), NewNamed("b")
+	if err := a.UnmarshalJSON([]byte(`{"op":"named","name":"b"}`)); err == nil || a.name != "a" {
+		t.Fatal("constructed element is decoded into:", a)
+	}
+
+	//proof decodes its argument into target
+	forth := func(x Element) Element {
+		x.ToNamed().UnmarshalJSON([]byte(`{"op":"named","name":"b"}`))
+		return x
+	}
+	back := func(x Element) Element {
+		x.ToNamed().UnmarshalJSON([]byte(`{"op":"named","name":"a"}`))
+		return x
+	}
+	if Verify(a, b, forth, back) {
+		t.Fatal("proof decoding its argument is verified")
+	}
+}
+
+func TestElementJSONErrors(t *testing.T) {
+	tests := []string{
+		`{"op":"compose","left":{"op":"identity"}}`,
+		`{"op":"inverse"}`,
+		`{"op":"named"}`,
+		`{"op":"power"}`,
+		`null`,
+	}
+	for _, src := range tests {
+		if el, err := UnmarshalElement([]byte(src)); err == nil {
+			t.Fatalf("%s is decoded as %s", src, el)
+		}
+	}
+}
+
+func TestProofJSON(t *testing.T) {
+	a, b := NewNamed("a"), NewNamed("b")
+	id := func(a Element) Element { return a }
+	right := Compose(Compose(b, Inverse(b)), a)
+
+	p, err := VerifyProof(a, right, func(x Element) Element {
+		return Unsimplify(x, true).Map(func(el Element) Element {
+			return el.ToIdentity().Unannihilate(b, false)
+		}, id)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := json.Marshal(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var q Proof
+	if err := json.Unmarshal(data, &q); err != nil {
+		t.Fatal(err)
+	}
+	if q.String() != p.String() {
+		t.Fatalf("decoded proof\n%s\nis not equal to encoded one\n%s", &q, p)
+	}
+	if err := Replay(q.Left, q.Right, q.Derivation()); err != nil {
+		t.Fatal(err)
+	}
+
+	var steps []Step
+	if err := json.Unmarshal([]byte(`[{"rule":"Unannihilate","path":["right","left"],"arg":{"op":"named","name":"b"}}]`), &steps); err != nil {
+		t.Fatal(err)
+	}
+	if s := steps[0]; s.Rule != RuleUnannihilate || s.Path.String() != "right.left" || !s.Arg.EqualLiteral(b) || s.Left {
+		t.Fatal("wrong decoded step:", s)
+	}
+}
@@ -0,0 +1,174 @@
+package gt
+
+import (
+	"fmt"
+)
+
+//Proven equation $left = right$. It holds for any elements substituted for its variables, because proof of lemma
+//does not depend on what they are.
+type Lemma struct {
+	name  string
+	proof *Proof
+}
+
+//Verifies proof "forth" that $left = right$ and makes lemma of it
+func NewLemma(name string, left, right Element, forth func(Element) Element) (*Lemma, error) {
+	p, err := VerifyProof(left, right, forth)
+	if err != nil {
+		return nil, err
+	}
+	return &Lemma{name: name, proof: p}, nil
+}
+
+func mustLemma(name, left, right string) *Lemma {
+	l, r := MustParse(left), MustParse(right)
+	p, err := Prove(l, r)
+	if err != nil {
+		panic(err)
+	}
+	lemma, err := NewLemma(name, l, r, p.Forth())
+	if err != nil {
+		panic(err)
+	}
+	return lemma
+}
+
+//returns name of lemma
+func (l *Lemma) Name() string {
+	return l.name
+}
+
+//returns left side of lemma
+func (l *Lemma) Left() Element {
+	return l.proof.Left.CloneLiteral()
+}
+
+//returns right side of lemma
+func (l *Lemma) Right() Element {
+	return l.proof.Right.CloneLiteral()
+}
+
+func (l *Lemma) String() string {
+	return fmt.Sprintf("%s: %s = %s", l.name, l.proof.Left, l.proof.Right)
+}
+
+//Returns lemma $right = left$
+func (l *Lemma) Reverse() *Lemma {
+	return &Lemma{name: l.name + "^-1", proof: l.proof.Inverse()}
+}
+
+//Turns "term" which is left side of lemma with substituted elements to right side of lemma with substituted elements.
+//Variables missing in "sub" are not substituted. If "sub" is nil it is found by Match. This is a step of proof.
+func ApplyLemma(term Element, lemma *Lemma, sub Substitution) Element {
+	n, err := TryApplyLemma(term, lemma, sub)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+//Same as ApplyLemma but returns *StepError instead of panicking
+func TryApplyLemma(term Element, lemma *Lemma, sub Substitution) (Element, error) {
+	if sub == nil {
+		sub, _ = Match(lemma.proof.Left, term)
+	}
+	left := Substitute(lemma.proof.Left, sub)
+	if !left.EqualLiteral(term) {
+		return nil, &StepError{Step: "ApplyLemma " + lemma.name, Pattern: "$" + FormatLaTeX(left, LaTeXOptions{}) + "$", Term: term}
+	}
+	//steps of proof of lemma made on substituted elements
+	n, err := run(func(el Element) Element {
+		for _, s := range lemma.proof.Steps {
+			s.Arg = Substitute(s.Arg, sub)
+			el = s.Step.Apply(el)
+		}
+		return el
+	}, term)
+	if err != nil {
+		return nil, err
+	}
+	if right := Substitute(lemma.proof.Right, sub); !n.EqualLiteral(right) {
+		panic(fmt.Sprintf("gt: lemma %s proved %s instead of %s", lemma.name, n, right))
+	}
+	return n, nil
+}
+
+//Lemma as proof. This is a step of proof.
+func LemmaApplier(lemma *Lemma, sub Substitution) func(Element) Element {
+	return func(el Element) Element {
+		return ApplyLemma(el, lemma, sub)
+	}
+}
+
+//Standard lemmas. Uniqueness of identity and of inverses hold under hypothesis, see IdentityUniqueness and
+//InverseUniqueness.
+var (
+	//$x\cdot (y\cdot (y^{-1}\cdot z)) = x\cdot z$
+	Cancellation = mustLemma("Cancellation", "?x*?y*?y^-1*?z", "?x*?z")
+	//$(x\cdot y)\cdot y^{-1} = x$
+	RightCancellation = mustLemma("RightCancellation", "(?x*?y)*?y^-1", "?x")
+	//$x^{-1}\cdot (x\cdot y) = y$
+	LeftCancellation = mustLemma("LeftCancellation", "?x^-1*?x*?y", "?y")
+	//$(x\cdot y)^{-1} = y^{-1}\cdot x^{-1}$
+	SocksShoes = mustLemma("SocksShoes", "(?x*?y)^-1", "?y^-1*?x^-1")
+)
+
+//returns proof turning $l$ to $r$ by relator $l\cdot r^{-1}$ number "i" of "p":
+//$l = l\cdot (r^{-1}\cdot r) = (l\cdot r^{-1})\cdot r = e\cdot r = r$
+func hypothesis(p *Presentation, i int, r Element) func(Element) Element {
+	return Sequence(
+		Unsimplifier(false),
+		At(Path{MoveRight}, Unannihilator(r.CloneLiteral(), true)),
+		Associator,
+		At(Path{MoveLeft}, p.Relation(i, Forward)),
+		Simplifier,
+	)
+}
+
+//Proves uniqueness of identity: if relator "i" of "p" is $(x\cdot y)\cdot y^{-1}$, i.e. $x\cdot y = y$, returns
+//proof of $x = e$ verified in "p" which rewrites $x\cdot y$ to $y$ by the hypothesis:
+//$x = x\cdot (y\cdot y^{-1}) = (x\cdot y)\cdot y^{-1} = y\cdot y^{-1} = e$
+func IdentityUniqueness(p *Presentation, i int) (*Proof, error) {
+	r, err := p.relator(i)
+	if err != nil {
+		return nil, err
+	}
+	c, ok := r.(*Composite)
+	if !ok {
+		return nil, fmt.Errorf("gt: relator %s is not of form (x*y)*y^-1", r)
+	}
+	xy, ok1 := c.left.(*Composite)
+	y, ok2 := c.right.(*Inversed)
+	if !ok1 || !ok2 || !xy.right.EqualLiteral(y.operand) {
+		return nil, fmt.Errorf("gt: relator %s is not of form (x*y)*y^-1", r)
+	}
+	forth := Sequence(
+		Unsimplifier(false),
+		At(Path{MoveRight}, Unannihilator(y.operand.CloneLiteral(), false)),
+		Associator,
+		At(Path{MoveLeft}, hypothesis(p, i, y.operand)),
+		Annihilator,
+	)
+	return p.VerifyProof(xy.left, NewIdentity(), forth)
+}
+
+//Proves uniqueness of inverses: if relator "i" of "p" is $x\cdot y$, i.e. $x\cdot y = e$, returns proof of
+//$y = x^{-1}$ verified in "p": $y = (x^{-1}\cdot x)\cdot y = x^{-1}\cdot (x\cdot y) = x^{-1}\cdot e = x^{-1}$
+func InverseUniqueness(p *Presentation, i int) (*Proof, error) {
+	r, err := p.relator(i)
+	if err != nil {
+		return nil, err
+	}
+	c, ok := r.(*Composite)
+	if !ok {
+		return nil, fmt.Errorf("gt: relator %s is not of form x*y", r)
+	}
+	forth := Sequence(
+		Unsimplifier(true),
+		At(Path{MoveLeft}, Unannihilator(c.left.CloneLiteral(), true)),
+		Unassociator,
+		At(Path{MoveRight}, p.Relation(i, Forward)),
+		Simplifier,
+	)
+	return p.VerifyProof(c.right, Inverse(c.left), forth)
+}
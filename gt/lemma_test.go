@@ -0,0 +1,150 @@
+package gt
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestApplyLemma(t *testing.T) {
+	d := MustParse("a*b*b^-1*c")
+	h := MustParse("a*c")
+	sub := Substitution{"x": NewNamed("a"), "y": NewNamed("b"), "z": NewNamed("c")}
+
+	forth := LemmaApplier(Cancellation, sub)
+	back := LemmaApplier(Cancellation.Reverse(), sub)
+	if err := Check(d, h, forth, back); err != nil {
+		t.Fatal(err)
+	}
+
+	//substitution of composites
+	d = MustParse("u*(p*q)^-1*(p*q)^-1^-1*e")
+	h = MustParse("u*e")
+	sub = Substitution{"x": NewNamed("u"), "y": MustParse("(p*q)^-1"), "z": NewIdentity()}
+	if err := CheckForth(d, h, LemmaApplier(Cancellation, sub)); err != nil {
+		t.Fatal(err)
+	}
+
+	//lemma applied to subterm
+	d = MustParse("c*(a*b)^-1")
+	h = MustParse("c*b^-1*a^-1")
+	sub = Substitution{"x": NewNamed("a"), "y": NewNamed("b")}
+	if err := CheckForth(d, h, At(Path{MoveRight}, LemmaApplier(SocksShoes, sub))); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestApplyLemmaChecksMatch(t *testing.T) {
+	sub := Substitution{"x": NewNamed("a"), "y": NewNamed("b")}
+
+	_, err := TryApplyLemma(MustParse("(a*b)^-1"), SocksShoes, Substitution{"x": NewNamed("b"), "y": NewNamed("a")})
+	var se *StepError
+	if !errors.As(err, &se) {
+		t.Fatal("lemma is applied to wrong element:", err)
+	}
+
+	if err := CheckForth(MustParse("(a*c)^-1"), MustParse("c^-1*a^-1"), LemmaApplier(SocksShoes, sub)); err == nil {
+		t.Fatal("lemma is applied to wrong element")
+	}
+
+	//unbound variables stay as they are
+	n, err := TryApplyLemma(MustParse("(a*?y)^-1"), SocksShoes, Substitution{"x": NewNamed("a")})
+	if err != nil || !n.EqualLiteral(MustParse("?y^-1*a^-1")) {
+		t.Fatal("wrong result of lemma with unbound variable:", n, err)
+	}
+
+	//named elements are not variables
+	l, err := NewLemma("Constant", MustParse("(a*?x)^-1"), MustParse("?x^-1*a^-1"), At(nil, Distributor))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := TryApplyLemma(MustParse("(b*c)^-1"), l, Substitution{"a": NewNamed("b"), "x": NewNamed("c")}); err == nil {
+		t.Fatal("named element is substituted")
+	}
+}
+
+func TestNewLemma(t *testing.T) {
+	left, right := MustParse("?x^-1^-1*?y"), MustParse("?x*?y")
+
+	l, err := NewLemma("DoubleInverse", left, right, At(Path{MoveLeft}, DoubleInverter))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !l.Left().EqualLiteral(left) || !l.Right().EqualLiteral(right) {
+		t.Fatal("wrong lemma", l)
+	}
+	sub := Substitution{"x": MustParse("a*b"), "y": NewIdentity()}
+	if err := CheckForth(MustParse("(a*b)^-1^-1*e"), MustParse("(a*b)*e"), LemmaApplier(l, sub)); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := NewLemma("Wrong", left, MustParse("y*x"), func(x Element) Element { return x }); err == nil {
+		t.Fatal("lemma with wrong proof is made")
+	}
+}
+
+func TestStandardLemmas(t *testing.T) {
+	for _, l := range []*Lemma{Cancellation, RightCancellation, LeftCancellation, SocksShoes} {
+		if !Equal(l.Left(), l.Right()) {
+			t.Fatal("wrong lemma", l)
+		}
+	}
+}
+
+func TestIdentityUniqueness(t *testing.T) {
+	//hypothesis $x\cdot y = y$ as relator
+	p := MustParsePresentation("<x, y, z | (x*y)*y^-1, z>")
+	proof, err := IdentityUniqueness(p, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !proof.Left.EqualLiteral(NewNamed("x")) || !proof.Right.EqualLiteral(NewIdentity()) {
+		t.Fatal("wrong proof:", proof)
+	}
+	//hypothesis rewrites $x\cdot y$, not whole relator
+	used := false
+	for _, s := range proof.Steps {
+		if s.Rule == RuleRelation {
+			used = true
+			if len(s.Path) == 0 || !s.Before.EqualLiteral(MustParse("(x*y)*y^-1")) {
+				t.Fatal("relator is applied to whole term:", proof)
+			}
+		}
+	}
+	if !used {
+		t.Fatal("hypothesis is not used:", proof)
+	}
+	if err := p.CheckForth(proof.Left, proof.Right, proof.Forth()); err != nil {
+		t.Fatal(err)
+	}
+	if CheckForth(proof.Left, proof.Right, proof.Forth()) == nil {
+		t.Fatal("proof using hypothesis is verified in free group")
+	}
+	for _, i := range []int{1, 2} {
+		if _, err := IdentityUniqueness(p, i); err == nil {
+			t.Fatal("uniqueness of identity is proved from relator", i)
+		}
+	}
+}
+
+func TestInverseUniqueness(t *testing.T) {
+	//hypothesis $a\cdot (b\cdot c) = e$ as relator
+	p := MustParsePresentation("<a, b, c | a*b*c, a>")
+	proof, err := InverseUniqueness(p, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !proof.Left.EqualLiteral(MustParse("b*c")) || !proof.Right.EqualLiteral(MustParse("a^-1")) {
+		t.Fatal("wrong proof:", proof)
+	}
+	if err := p.Check(proof.Left, proof.Right, proof.Forth(), proof.Inverse().Forth()); err != nil {
+		t.Fatal(err)
+	}
+	if CheckForth(proof.Left, proof.Right, proof.Forth()) == nil {
+		t.Fatal("proof using hypothesis is verified in free group")
+	}
+	for _, i := range []int{1, 2} {
+		if _, err := InverseUniqueness(p, i); err == nil {
+			t.Fatal("uniqueness of inverses is proved from relator", i)
+		}
+	}
+}
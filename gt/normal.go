@@ -0,0 +1,248 @@
+package gt
+
+import (
+	"fmt"
+)
+
+//Returns normal form of element in free group: right associated composite of named elements, variables and their
+//inversions without $a\cdot a^{-1}$ and $a^{-1}\cdot a$, or $e$
+func Normalize(el Element) Element {
+	return fromWord(reduce(el))
+}
+
+//Checks whether two elements are equal in free group
+func Equal(a, b Element) bool {
+	return equalWords(reduce(a), reduce(b))
+}
+
+//Returns proof that $el = Normalize(el)$ made of steps Associate, Unassociate, Annihilate, Unannihilate, Simplify and
+//Unsimplify
+func NormalizeProof(el Element) (*Proof, error) {
+	p, err := newProver(el)
+	if err != nil {
+		return nil, err
+	}
+	start := p.term
+	p.normalize(nil)
+	return &Proof{Left: start, Right: p.term, Steps: p.steps}, nil
+}
+
+//named element, variable or inversion of one of them
+type letter struct {
+	name  string
+	isVar bool
+	inv   bool
+}
+
+func (l letter) inverse() letter {
+	l.inv = !l.inv
+	return l
+}
+
+//returns reduced word of element in free group
+func reduce(el Element) []letter {
+	return reduceInto(nil, el, false)
+}
+
+//appends word of element (or of its inversion if "inv") to reduced word "w" keeping it reduced
+func reduceInto(w []letter, el Element, inv bool) []letter {
+	switch c := el.(type) {
+	case *Composite:
+		if inv {
+			return reduceInto(reduceInto(w, c.right, inv), c.left, inv)
+		}
+		return reduceInto(reduceInto(w, c.left, inv), c.right, inv)
+	case *Inversed:
+		return reduceInto(w, c.operand, !inv)
+	case *Named:
+		return appendLetter(w, letter{name: c.name, inv: inv})
+	case *Var:
+		return appendLetter(w, letter{name: c.name, isVar: true, inv: inv})
+	}
+	return w
+}
+
+func appendLetter(w []letter, l letter) []letter {
+	if n := len(w); n > 0 && w[n-1] == l.inverse() {
+		return w[:n-1]
+	}
+	return append(w, l)
+}
+
+//builds right associated composite of letters or identity for empty word
+func fromWord(w []letter) Element {
+	if len(w) == 0 {
+		return NewIdentity()
+	}
+	el := fromLetter(w[len(w)-1])
+	for i := len(w) - 2; i >= 0; i-- {
+		el = Compose(fromLetter(w[i]), el)
+	}
+	return el
+}
+
+func fromLetter(l letter) Element {
+	var el Element = NewNamed(l.name)
+	if l.isVar {
+		el = NewVar(l.name)
+	}
+	if l.inv {
+		return Inverse(el)
+	}
+	return el
+}
+
+//checks whether element is letter and returns it
+func asLetter(el Element) (letter, bool) {
+	switch c := el.(type) {
+	case *Named:
+		return letter{name: c.name}, true
+	case *Var:
+		return letter{name: c.name, isVar: true}, true
+	case *Inversed:
+		if l, ok := asLetter(c.operand); ok && !l.inv {
+			return l.inverse(), true
+		}
+	}
+	return letter{}, false
+}
+
+//Makes proofs by applying steps to term and recording them
+type prover struct {
+	term  Element
+	steps []ProofStep
+	//presentation of relation steps
+	pres *Presentation
+}
+
+func newProver(term Element) (*prover, error) {
+	t, err := copyTerm(term)
+	if err != nil {
+		return nil, err
+	}
+	return &prover{term: t}, nil
+}
+
+func (p *prover) at(path Path) Element {
+	el, err := subterm(p.term, path)
+	if err != nil {
+		panic(err)
+	}
+	return el
+}
+
+//applies step and records it. Panics if step fails: prover never makes wrong steps unless there is a bug.
+func (p *prover) apply(s Step) {
+	before := p.at(s.Path)
+	term, err := applyStep(p.term, s, p.pres)
+	if err != nil {
+		panic(fmt.Sprintf("gt: prover made wrong step %s: %v", s, err))
+	}
+	p.term = term
+	p.steps = append(p.steps, ProofStep{Step: s, Before: before, After: p.at(s.Path)})
+}
+
+//turns subterm at "path" to its normal form: right associated composite of letters without $a\cdot a^{-1}$ and
+//$a^{-1}\cdot a$ or single $e$
+func (p *prover) normalize(path Path) {
+	switch p.at(path).(type) {
+	case *Composite:
+		p.normalize(path.to(MoveLeft))
+		p.normalize(path.to(MoveRight))
+		p.concat(path)
+	case *Inversed:
+		p.normalize(path.to(MoveOperand))
+		p.invert(path)
+	}
+}
+
+//turns $x\cdot y$ at "path" with normal $x$ and $y$ to normal form
+func (p *prover) concat(path Path) {
+	c := p.at(path).(*Composite)
+	_, li := c.left.(*Identity)
+	_, ri := c.right.(*Identity)
+	switch {
+	case li || ri:
+		p.apply(Step{Rule: RuleSimplify, Path: path})
+	case isComposite(c.left):
+		//$(l\cdot x)\cdot y$ to $l\cdot (x\cdot y)$
+		p.apply(Step{Rule: RuleUnassociate, Path: path})
+		p.concat(path.to(MoveRight))
+		p.cancel(path)
+	default:
+		p.cancel(path)
+	}
+}
+
+//turns $l\cdot x$ at "path" with letter $l$ and normal $x$ to normal form
+func (p *prover) cancel(path Path) {
+	c := p.at(path).(*Composite)
+	l, _ := asLetter(c.left)
+	switch r := c.right.(type) {
+	case *Identity:
+		p.apply(Step{Rule: RuleSimplify, Path: path})
+	case *Composite:
+		if m, _ := asLetter(r.left); m == l.inverse() {
+			p.apply(Step{Rule: RuleAssociate, Path: path})
+			p.apply(Step{Rule: RuleAnnihilate, Path: path.to(MoveLeft)})
+			p.apply(Step{Rule: RuleSimplify, Path: path})
+		}
+	default:
+		if m, _ := asLetter(r); m == l.inverse() {
+			p.apply(Step{Rule: RuleAnnihilate, Path: path})
+		}
+	}
+}
+
+//turns $x^{-1}$ at "path" with normal $x$ to normal form
+func (p *prover) invert(path Path) {
+	c := p.at(path).(*Inversed)
+	left, right := path.to(MoveLeft), path.to(MoveRight)
+	switch x := c.operand.(type) {
+	case *Identity:
+		//$e^{-1} = e\cdot e^{-1} = e$
+		p.apply(Step{Rule: RuleUnsimplify, Path: path, Left: true})
+		p.apply(Step{Rule: RuleAnnihilate, Path: path})
+	case *Inversed:
+		//$(a^{-1})^{-1} = e\cdot (a^{-1})^{-1} = (a\cdot a^{-1})\cdot (a^{-1})^{-1} = a\cdot (a^{-1}\cdot (a^{-1})^{-1}) = a\cdot e = a$
+		p.apply(Step{Rule: RuleUnsimplify, Path: path, Left: true})
+		p.apply(Step{Rule: RuleUnannihilate, Path: left, Arg: x.operand, Left: false})
+		p.apply(Step{Rule: RuleUnassociate, Path: path})
+		p.apply(Step{Rule: RuleAnnihilate, Path: right})
+		p.apply(Step{Rule: RuleSimplify, Path: path})
+	case *Composite:
+		//$(l\cdot r)^{-1} = r^{-1}\cdot l^{-1}$, see socksShoes
+		p.socksShoes(path, x.left, x.right)
+		p.invert(left)
+		p.invert(right)
+		p.concat(path)
+	}
+}
+
+//turns $(l\cdot r)^{-1}$ at "path" to $r^{-1}\cdot l^{-1}$
+func (p *prover) socksShoes(path Path, l, r Element) {
+	right := path.to(MoveRight)
+	rr := right.to(MoveRight)
+	//$x\cdot e$ where $x = (l\cdot r)^{-1}$
+	p.apply(Step{Rule: RuleUnsimplify, Path: path, Left: false})
+	//$x\cdot (l\cdot l^{-1})$
+	p.apply(Step{Rule: RuleUnannihilate, Path: right, Arg: l, Left: false})
+	//$x\cdot (l\cdot (e\cdot l^{-1}))$
+	p.apply(Step{Rule: RuleUnsimplify, Path: rr, Left: true})
+	//$x\cdot (l\cdot ((r\cdot r^{-1})\cdot l^{-1}))$
+	p.apply(Step{Rule: RuleUnannihilate, Path: rr.to(MoveLeft), Arg: r, Left: false})
+	//$x\cdot (l\cdot (r\cdot (r^{-1}\cdot l^{-1})))$
+	p.apply(Step{Rule: RuleUnassociate, Path: rr})
+	//$x\cdot ((l\cdot r)\cdot (r^{-1}\cdot l^{-1}))$
+	p.apply(Step{Rule: RuleAssociate, Path: right})
+	//$(x\cdot (l\cdot r))\cdot (r^{-1}\cdot l^{-1})$
+	p.apply(Step{Rule: RuleAssociate, Path: path})
+	//$e\cdot (r^{-1}\cdot l^{-1})$
+	p.apply(Step{Rule: RuleAnnihilate, Path: path.to(MoveLeft)})
+	p.apply(Step{Rule: RuleSimplify, Path: path})
+}
+
+func isComposite(el Element) bool {
+	_, ok := el.(*Composite)
+	return ok
+}
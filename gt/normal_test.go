@@ -0,0 +1,46 @@
+package gt
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		el, normal string
+	}{
+		{"a", "a"},
+		{"e*e^-1", "e"},
+		{"(a*b)*c", "a*b*c"},
+		{"a*(b*(b^-1*c))", "a*c"},
+		{"(a*b)^-1", "b^-1*a^-1"},
+		{"a^-1^-1^-1", "a^-1"},
+		{"(a*b^-1)*(b*a^-1)", "e"},
+		{"((a*b)^-1*c)^-1", "c^-1*a*b"},
+	}
+	for _, tt := range tests {
+		el := MustParse(tt.el)
+		n := Normalize(el)
+		if fmt.Sprint(n) != tt.normal {
+			t.Fatalf("normal form of %s is %s, expected %s", tt.el, n, tt.normal)
+		}
+		if !Equal(el, MustParse(tt.normal)) {
+			t.Fatalf("%s != %s", tt.el, tt.normal)
+		}
+
+		p, err := NormalizeProof(el)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !p.Right.EqualLiteral(n) {
+			t.Fatalf("proof of normal form of %s results in %s", tt.el, p.Right)
+		}
+		if err := CheckForth(el, n, p.Forth()); err != nil {
+			t.Fatalf("proof of normal form of %s is not verified: %v", tt.el, err)
+		}
+	}
+
+	if Equal(MustParse("a*b"), MustParse("b*a")) {
+		t.Fatal("free group is commutative")
+	}
+}
@@ -0,0 +1,166 @@
+package gt
+
+import (
+	"fmt"
+	"unicode"
+)
+
+//Associativity of products without parentheses, e.g. $a\cdot b\cdot c$
+type Associativity int
+
+const (
+	//$a\cdot b\cdot c$ is $a\cdot (b\cdot c)$
+	RightAssoc Associativity = iota
+	//$a\cdot b\cdot c$ is $(a\cdot b)\cdot c$
+	LeftAssoc
+)
+
+//Syntax error of parsed expression at "Column" (counting from 1)
+type ParseError struct {
+	Column int
+	Msg    string
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("column %d: %s", e.Column, e.Msg)
+}
+
+//Parses expression like "a*(b*(b^-1*c))" where "*" is composition, "^-1" is inversion, "e" is identity and "?x" is
+//variable. Products without parentheses are right associative.
+func Parse(s string) (Element, error) {
+	return ParseAssoc(s, RightAssoc)
+}
+
+//Same as Parse but with given associativity of products without parentheses
+func ParseAssoc(s string, assoc Associativity) (Element, error) {
+	p := &parser{src: []rune(s), assoc: assoc}
+	el, err := p.product()
+	if err != nil {
+		return nil, err
+	}
+	if p.skipSpace(); p.pos < len(p.src) {
+		return nil, p.errorf("unexpected %q", p.src[p.pos])
+	}
+	return el, nil
+}
+
+//Same as Parse but panics on error
+func MustParse(s string) Element {
+	el, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return el
+}
+
+type parser struct {
+	src   []rune
+	pos   int
+	assoc Associativity
+}
+
+func (p *parser) errorf(format string, args ...interface{}) error {
+	return &ParseError{Column: p.pos + 1, Msg: fmt.Sprintf(format, args...)}
+}
+
+func (p *parser) skipSpace() {
+	for p.pos < len(p.src) && unicode.IsSpace(p.src[p.pos]) {
+		p.pos++
+	}
+}
+
+//consumes "s" if it follows
+func (p *parser) accept(s string) bool {
+	p.skipSpace()
+	r := []rune(s)
+	if p.pos+len(r) > len(p.src) || string(p.src[p.pos:p.pos+len(r)]) != s {
+		return false
+	}
+	p.pos += len(r)
+	return true
+}
+
+//product := power ("*" power)*
+func (p *parser) product() (Element, error) {
+	factors := []Element{}
+	for {
+		f, err := p.power()
+		if err != nil {
+			return nil, err
+		}
+		factors = append(factors, f)
+		if !p.accept("*") {
+			break
+		}
+	}
+	if p.assoc == LeftAssoc {
+		el := factors[0]
+		for _, f := range factors[1:] {
+			el = Compose(el, f)
+		}
+		return el, nil
+	}
+	el := factors[len(factors)-1]
+	for i := len(factors) - 2; i >= 0; i-- {
+		el = Compose(factors[i], el)
+	}
+	return el, nil
+}
+
+//power := atom ("^-1")*
+func (p *parser) power() (Element, error) {
+	el, err := p.atom()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept("^") {
+		if !p.accept("-1") {
+			p.skipSpace()
+			return nil, p.errorf("expected -1 after ^")
+		}
+		el = Inverse(el)
+	}
+	return el, nil
+}
+
+//atom := name | "e" | "?" name | "(" product ")"
+func (p *parser) atom() (Element, error) {
+	p.skipSpace()
+	if p.pos >= len(p.src) {
+		return nil, p.errorf("unexpected end of expression")
+	}
+	if p.accept("(") {
+		el, err := p.product()
+		if err != nil {
+			return nil, err
+		}
+		if !p.accept(")") {
+			p.skipSpace()
+			return nil, p.errorf("expected )")
+		}
+		return el, nil
+	}
+	isVar := p.accept("?")
+	start := p.pos
+	for p.pos < len(p.src) && isNameRune(p.src[p.pos], p.pos == start) {
+		p.pos++
+	}
+	if p.pos == start {
+		if p.pos == len(p.src) {
+			return nil, p.errorf("unexpected end of expression")
+		}
+		return nil, p.errorf("unexpected %q", p.src[p.pos])
+	}
+	name := string(p.src[start:p.pos])
+	if isVar {
+		return NewVar(name), nil
+	}
+	if name == "e" {
+		return NewIdentity(), nil
+	}
+	return NewNamed(name), nil
+}
+
+func isNameRune(r rune, first bool) bool {
+	return r == '_' || unicode.IsLetter(r) || !first && unicode.IsDigit(r)
+}
@@ -0,0 +1,61 @@
+package gt
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	a, b, c := NewNamed("a"), NewNamed("b"), NewNamed("c")
+
+	tests := []struct {
+		src  string
+		want Element
+	}{
+		{"a", a},
+		{"e", NewIdentity()},
+		{"a*(b*(b^-1*c))", Compose(a, Compose(b, Compose(Inverse(b), c)))},
+		{"a*b*c", Compose(a, Compose(b, c))},
+		{"(a*b)*c", Compose(Compose(a, b), c)},
+		{" ( a * b ) ^-1 ", Inverse(Compose(a, b))},
+		{"a^-1^-1", Inverse(Inverse(a))},
+		{"x1*e", Compose(NewNamed("x1"), NewIdentity())},
+		{"?x*x", Compose(NewVar("x"), NewNamed("x"))},
+	}
+	for _, tt := range tests {
+		el, err := Parse(tt.src)
+		if err != nil {
+			t.Fatalf("%q: %v", tt.src, err)
+		}
+		if !el.EqualLiteral(tt.want) {
+			t.Fatalf("%q is parsed as %s", tt.src, format(el))
+		}
+	}
+
+	el, err := ParseAssoc("a*b*c", LeftAssoc)
+	if err != nil || !el.EqualLiteral(Compose(Compose(a, b), c)) {
+		t.Fatal("'LeftAssoc' is ignored:", err)
+	}
+}
+
+func TestParseErrorColumn(t *testing.T) {
+	tests := []struct {
+		src    string
+		column int
+	}{
+		{"", 1},
+		{"a*", 3},
+		{"a*(b*c", 7},
+		{"a^2", 3},
+		{"a b", 3},
+		{"a*)", 3},
+		{"a*?", 4},
+	}
+	for _, tt := range tests {
+		_, err := Parse(tt.src)
+		var pe *ParseError
+		if !errors.As(err, &pe) || pe.Column != tt.column {
+			t.Fatalf("%q: wrong error %v, expected column %d", tt.src, err, tt.column)
+		}
+	}
+}
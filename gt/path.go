@@ -0,0 +1,204 @@
+package gt
+
+import (
+	"fmt"
+	"strings"
+)
+
+//Move from element to its subterm
+type Move int
+
+const (
+	//To left element of composite
+	MoveLeft Move = iota
+	//To right element of composite
+	MoveRight
+	//To operand of inversion
+	MoveOperand
+)
+
+func (m Move) String() string {
+	switch m {
+	case MoveLeft:
+		return "left"
+	case MoveRight:
+		return "right"
+	case MoveOperand:
+		return "operand"
+	}
+	return fmt.Sprintf("Move(%d)", int(m))
+}
+
+//Position of subterm in element: sequence of moves from the root
+type Path []Move
+
+func (p Path) String() string {
+	if len(p) == 0 {
+		return "root"
+	}
+	s := make([]string, len(p))
+	for i, m := range p {
+		s[i] = m.String()
+	}
+	return strings.Join(s, ".")
+}
+
+//returns path "m" followed by "p"
+func (p Path) under(m Move) Path {
+	return append(Path{m}, p...)
+}
+
+//Applies proof "rule" to subterm of "term" at "path". This is a step of proof iff "rule" is a step.
+func RewriteAt(term Element, path Path, rule func(Element) Element) Element {
+	if len(path) == 0 {
+		return rule(term)
+	}
+	id := func(el Element) Element { return el }
+	sub := func(el Element) Element { return RewriteAt(el, path[1:], rule) }
+	switch path[0] {
+	case MoveLeft:
+		return term.ToComposite().Map(sub, id)
+	case MoveRight:
+		return term.ToComposite().Map(id, sub)
+	case MoveOperand:
+		return term.ToInversed().Map(sub)
+	}
+	panic(fmt.Sprintf("invalid move %d", int(path[0])))
+}
+
+//Calls "f" for subterms of element and then for element itself, left subterm first. Subterms are passed without
+//copying, so walk takes linear time. Stops at first error of "f" and returns it.
+func Postorder(el Element, f func(Element) error) error {
+	switch c := el.(type) {
+	case *Composite:
+		if err := Postorder(c.left, f); err != nil {
+			return err
+		}
+		if err := Postorder(c.right, f); err != nil {
+			return err
+		}
+	case *Inversed:
+		if err := Postorder(c.operand, f); err != nil {
+			return err
+		}
+	}
+	return f(el)
+}
+
+//Returns proof applying "rule" at "path" (see RewriteAt). This is a step of proof iff "rule" is a step.
+func At(path Path, rule func(Element) Element) func(Element) Element {
+	return func(el Element) Element {
+		return RewriteAt(el, path, rule)
+	}
+}
+
+//Applies proofs one after another. This is a step of proof iff all "rules" are steps.
+func Sequence(rules ...func(Element) Element) func(Element) Element {
+	return func(el Element) Element {
+		for _, rule := range rules {
+			el = rule(el)
+		}
+		return el
+	}
+}
+
+//Applies step to "term" at step's path. This is a step of proof.
+func (s Step) Apply(term Element) Element {
+	return RewriteAt(term, s.Path, s.rule())
+}
+
+//returns rule of step as function applicable to the subterm
+func (s Step) rule() func(Element) Element {
+	switch s.Rule {
+	case RuleAssociate:
+		return Associator
+	case RuleUnassociate:
+		return Unassociator
+	case RuleAnnihilate:
+		return Annihilator
+	case RuleUnannihilate:
+		return Unannihilator(s.Arg, s.Left)
+	case RuleSimplify:
+		return Simplifier
+	case RuleUnsimplify:
+		return Unsimplifier(s.Left)
+	case RuleDoubleInverse:
+		return DoubleInverter
+	case RuleUndoubleInverse:
+		return UndoubleInverter
+	case RuleDistributeOverProduct:
+		return Distributor
+	case RuleUndistributeOverProduct:
+		return Undistributor
+	case RuleRelation:
+		if s.pres == nil {
+			panic(fmt.Sprintf("%s requires presentation", s.Rule))
+		}
+		return s.pres.Relation(s.Relator, s.Direction)
+	}
+	panic(fmt.Sprintf("unknown rule %q", s.Rule))
+}
+
+//Associate as proof. This is a step of proof.
+func Associator(el Element) Element {
+	return el.ToComposite().Associate()
+}
+
+//Unassociate as proof. This is a step of proof.
+func Unassociator(el Element) Element {
+	return el.ToComposite().Unassociate()
+}
+
+//Annihilate as proof. This is a step of proof.
+func Annihilator(el Element) Element {
+	return el.ToComposite().Annihilate()
+}
+
+//Unannihilate with given arguments as proof. This is a step of proof.
+func Unannihilator(arg Element, left bool) func(Element) Element {
+	return func(el Element) Element {
+		return el.ToIdentity().Unannihilate(arg, left)
+	}
+}
+
+//Simplify as proof. This is a step of proof.
+func Simplifier(el Element) Element {
+	return el.ToComposite().Simplify()
+}
+
+//Unsimplify with given argument as proof. This is a step of proof.
+func Unsimplifier(left bool) func(Element) Element {
+	return func(el Element) Element {
+		return Unsimplify(el, left)
+	}
+}
+
+//returns subterm of "term" at "path"
+func subterm(term Element, path Path) (Element, error) {
+	for i, m := range path {
+		switch c := term.(type) {
+		case *Composite:
+			if m == MoveLeft {
+				term = c.left
+				continue
+			} else if m == MoveRight {
+				term = c.right
+				continue
+			}
+		case *Inversed:
+			if m == MoveOperand {
+				term = c.operand
+				continue
+			}
+		}
+		return nil, fmt.Errorf("%s has no %s subterm at %s", format(term), m, path[:i])
+	}
+	return term, nil
+}
+
+//returns path "p" followed by "m"
+func (p Path) to(m Move) Path {
+	n := make(Path, len(p), len(p)+1)
+	copy(n, p)
+	return append(n, m)
+}
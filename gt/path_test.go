@@ -0,0 +1,61 @@
+package gt
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestRewriteAtIsStep(t *testing.T) {
+	d := MustParse("a*b*b^-1*c")
+	h := MustParse("a*c")
+
+	proofForth := Sequence(
+		At(Path{MoveRight}, Associator),
+		At(Path{MoveRight, MoveLeft}, Annihilator),
+		At(Path{MoveRight}, Simplifier),
+	)
+	if err := CheckForth(d, h, proofForth); err != nil {
+		t.Fatal(err)
+	}
+
+	proofBack := Sequence(
+		Step{Rule: RuleUnsimplify, Path: Path{MoveRight}, Left: true}.Apply,
+		Step{Rule: RuleUnannihilate, Path: Path{MoveRight, MoveLeft}, Arg: NewNamed("b")}.Apply,
+		Step{Rule: RuleUnassociate, Path: Path{MoveRight}}.Apply,
+	)
+	if err := Check(d, h, proofForth, proofBack); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRewriteAtIsNotStep(t *testing.T) {
+	d := MustParse("(a^-1*b)^-1")
+
+	e := RewriteAt(d, Path{MoveOperand, MoveLeft}, func(el Element) Element { return NewNamed("b") })
+	if e.Same(d) || !e.EqualLiteral(MustParse("(b*b)^-1")) {
+		t.Fatal("'RewriteAt' is a step when must not be:", e)
+	}
+
+	e = RewriteAt(d, Path{MoveOperand, MoveLeft}, func(el Element) Element { return el })
+	if !e.Same(d) {
+		t.Fatal("'RewriteAt' is not a step when must be")
+	}
+
+	if err := CheckForth(d, d, func(x Element) Element {
+		return RewriteAt(x, Path{MoveLeft}, Simplifier)
+	}); err == nil {
+		t.Fatal("'RewriteAt' accepts wrong path")
+	}
+}
+
+func TestPostorder(t *testing.T) {
+	var visited []string
+	err := Postorder(MustParse("(a*b^-1)*c"), func(el Element) error {
+		visited = append(visited, format(el))
+		return nil
+	})
+	want := []string{"a", "b", "b^-1", "a*b^-1", "c", "(a*b^-1)*c"}
+	if err != nil || fmt.Sprint(visited) != fmt.Sprint(want) {
+		t.Fatal("wrong postorder:", visited, err)
+	}
+}
@@ -0,0 +1,175 @@
+package gt
+
+import (
+	"bytes"
+	"fmt"
+)
+
+//Permutation of $\{0, \ldots, n-1\}$ mapping i to p[i]
+type Perm []int
+
+//Returns identity permutation of degree "n"
+func IdentityPerm(n int) Perm {
+	p := make(Perm, n)
+	for i := range p {
+		p[i] = i
+	}
+	return p
+}
+
+//Creates permutation mapping i to images[i]. Fails if images are not a permutation of $\{0, \ldots, n-1\}$.
+func NewPerm(images ...int) (Perm, error) {
+	seen := make([]bool, len(images))
+	for i, j := range images {
+		if j < 0 || j >= len(images) || seen[j] {
+			return nil, fmt.Errorf("gt: %v is not permutation: image %d of %d", images, j, i)
+		}
+		seen[j] = true
+	}
+	return append(Perm(nil), images...), nil
+}
+
+//Creates permutation of degree "n" from disjoint cycles, e.g. FromCycles(4, []int{0, 1, 2}) maps 0 to 1, 1 to 2, 2 to
+//0 and fixes 3
+func FromCycles(n int, cycles ...[]int) (Perm, error) {
+	p := IdentityPerm(n)
+	moved := make([]bool, n)
+	for _, c := range cycles {
+		for i, j := range c {
+			if j < 0 || j >= n || moved[j] {
+				return nil, fmt.Errorf("gt: %v are not disjoint cycles of degree %d", cycles, n)
+			}
+			moved[j] = true
+			p[j] = c[(i+1)%len(c)]
+		}
+	}
+	return p, nil
+}
+
+//Same as FromCycles but panics on error
+func MustFromCycles(n int, cycles ...[]int) Perm {
+	p, err := FromCycles(n, cycles...)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+//Returns composition $p\cdot q$: "p" is applied first, then "q", i.e. i is mapped to q[p[i]]. Permutations must be
+//of same degree.
+func (p Perm) Compose(q Perm) Perm {
+	if len(p) != len(q) {
+		panic(fmt.Sprintf("gt: composition of permutations of degrees %d and %d", len(p), len(q)))
+	}
+	r := make(Perm, len(p))
+	for i, j := range p {
+		r[i] = q[j]
+	}
+	return r
+}
+
+//Returns inverse permutation
+func (p Perm) Inverse() Perm {
+	r := make(Perm, len(p))
+	for i, j := range p {
+		r[j] = i
+	}
+	return r
+}
+
+//Checks whether permutations are equal
+func (p Perm) Equal(q Perm) bool {
+	if len(p) != len(q) {
+		return false
+	}
+	for i := range p {
+		if p[i] != q[i] {
+			return false
+		}
+	}
+	return true
+}
+
+//Checks whether permutation fixes every point
+func (p Perm) IsIdentity() bool {
+	for i, j := range p {
+		if i != j {
+			return false
+		}
+	}
+	return true
+}
+
+//Formats permutation as product of disjoint cycles, e.g. "(0 1 2)(3 4)", or "()" for identity
+func (p Perm) String() string {
+	var b bytes.Buffer
+	seen := make([]bool, len(p))
+	for i := range p {
+		if seen[i] || p[i] == i {
+			continue
+		}
+		b.WriteString("(")
+		for j := i; !seen[j]; j = p[j] {
+			if j != i {
+				b.WriteString(" ")
+			}
+			fmt.Fprint(&b, j)
+			seen[j] = true
+		}
+		b.WriteString(")")
+	}
+	if b.Len() == 0 {
+		return "()"
+	}
+	return b.String()
+}
+
+//Evaluates element in permutation group where named elements are mapped to permutations by "assignment": composites
+//are evaluated to compositions (see Perm.Compose), inversions to inverse permutations and $e$ to identity
+//permutation. All permutations must be of same degree. Fails if element has named element without permutation or
+//variable.
+func Eval(el Element, assignment map[string]Perm) (Perm, error) {
+	n := -1
+	for name, p := range assignment {
+		if n >= 0 && len(p) != n {
+			return nil, fmt.Errorf("gt: permutation of %s is of degree %d, expected %d", name, len(p), n)
+		}
+		n = len(p)
+	}
+	if n < 0 {
+		n = 0
+	}
+	return eval(el, assignment, n)
+}
+
+func eval(el Element, assignment map[string]Perm, n int) (Perm, error) {
+	switch c := el.(type) {
+	case *Composite:
+		l, err := eval(c.left, assignment, n)
+		if err != nil {
+			return nil, err
+		}
+		r, err := eval(c.right, assignment, n)
+		if err != nil {
+			return nil, err
+		}
+		return l.Compose(r), nil
+	case *Inversed:
+		p, err := eval(c.operand, assignment, n)
+		if err != nil {
+			return nil, err
+		}
+		return p.Inverse(), nil
+	case *Named:
+		p, ok := assignment[c.name]
+		if !ok {
+			return nil, fmt.Errorf("gt: no permutation of %s", c.name)
+		}
+		return p, nil
+	case *Identity:
+		return IdentityPerm(n), nil
+	case *Var:
+		return nil, fmt.Errorf("gt: can't evaluate variable %s", format(c))
+	}
+	return nil, fmt.Errorf("gt: can't evaluate element of type %T", el)
+}
@@ -0,0 +1,75 @@
+package gt
+
+import (
+	"testing"
+)
+
+func TestPerm(t *testing.T) {
+	a := MustFromCycles(4, []int{0, 1, 2})
+	b := MustFromCycles(4, []int{2, 3})
+	if s := a.Compose(b).String(); s != "(0 1 3 2)" {
+		t.Fatal("wrong composition:", s)
+	}
+	if !a.Compose(a.Inverse()).IsIdentity() || a.IsIdentity() {
+		t.Fatal("wrong inverse")
+	}
+	if IdentityPerm(3).String() != "()" {
+		t.Fatal("wrong identity")
+	}
+	if _, err := NewPerm(0, 2, 2); err == nil {
+		t.Fatal("not permutation is accepted")
+	}
+	if _, err := FromCycles(3, []int{0, 1}, []int{1, 2}); err == nil {
+		t.Fatal("not disjoint cycles are accepted")
+	}
+}
+
+func TestEval(t *testing.T) {
+	s3 := map[string]Perm{"a": MustFromCycles(3, []int{0, 1}), "b": MustFromCycles(3, []int{0, 1, 2})}
+	tests := []struct {
+		src  string
+		want Perm
+	}{
+		{"a*a", IdentityPerm(3)},
+		{"b*b*b", IdentityPerm(3)},
+		{"(a*b)^-1", MustFromCycles(3, []int{0, 2})},
+		{"b*a", MustFromCycles(3, []int{1, 2})},
+		{"e", IdentityPerm(3)},
+	}
+	for _, tt := range tests {
+		p, err := Eval(MustParse(tt.src), s3)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !p.Equal(tt.want) {
+			t.Fatalf("%s is %s, expected %s", tt.src, p, tt.want)
+		}
+	}
+
+	for _, src := range []string{"a*c", "?x", "a"} {
+		bad := map[string]Perm{"a": IdentityPerm(2), "b": IdentityPerm(3)}
+		if src != "a" {
+			bad = s3
+		}
+		if _, err := Eval(MustParse(src), bad); err == nil {
+			t.Fatal(src, "is evaluated")
+		}
+	}
+}
+
+func TestEvalCosets(t *testing.T) {
+	p := MustParsePresentation("<a, b | a*a, b*b*b, (a*b)*(a*b)>")
+	table, err := CosetEnumerate(p, nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	perms := table.Permutations()
+	for _, r := range p.Relators() {
+		if q, err := Eval(r, perms); err != nil || !q.IsIdentity() {
+			t.Fatal("relator", r, "is not identity:", q, err)
+		}
+	}
+	if q, _ := Eval(MustParse("a*b*a^-1*b^-1"), perms); q.IsIdentity() {
+		t.Fatal("representation is not faithful")
+	}
+}
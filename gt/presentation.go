@@ -0,0 +1,253 @@
+package gt
+
+import (
+	"fmt"
+	"strings"
+)
+
+//Name of step ApplyRelation
+const RuleRelation = "Relation"
+
+//Direction of ApplyRelation
+type Direction int
+
+const (
+	//Turns relator to $e$
+	Forward Direction = iota
+	//Turns $e$ to relator
+	Backward
+)
+
+func (d Direction) String() string {
+	switch d {
+	case Forward:
+		return "forward"
+	case Backward:
+		return "backward"
+	}
+	return fmt.Sprintf("Direction(%d)", int(d))
+}
+
+func (d Direction) MarshalText() ([]byte, error) {
+	switch d {
+	case Forward, Backward:
+		return []byte(d.String()), nil
+	}
+	return nil, fmt.Errorf("gt: invalid direction %d", int(d))
+}
+
+func (d *Direction) UnmarshalText(text []byte) error {
+	for _, v := range []Direction{Forward, Backward} {
+		if string(text) == v.String() {
+			*d = v
+			return nil
+		}
+	}
+	return fmt.Errorf("gt: invalid direction %q", text)
+}
+
+//Finitely presented group $\langle generators | relators\rangle$: group of products of generators where relators are
+//equal to $e$. Proofs using its relations are verified by its Verify methods only.
+type Presentation struct {
+	generators []string
+	relators   []Element
+}
+
+//Creates presentation. Relators must be made of named elements listed in "generators".
+func NewPresentation(generators []string, relators ...Element) (*Presentation, error) {
+	p := &Presentation{generators: append([]string(nil), generators...)}
+	known := map[string]bool{}
+	for _, g := range generators {
+		if known[g] {
+			return nil, fmt.Errorf("gt: duplicate generator %q", g)
+		}
+		known[g] = true
+	}
+	for i, r := range relators {
+		c, err := copyTerm(r)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkGenerators(c, known); err != nil {
+			return nil, fmt.Errorf("gt: relator %d: %v", i, err)
+		}
+		p.relators = append(p.relators, c)
+	}
+	return p, nil
+}
+
+func checkGenerators(el Element, known map[string]bool) error {
+	switch c := el.(type) {
+	case *Composite:
+		if err := checkGenerators(c.left, known); err != nil {
+			return err
+		}
+		return checkGenerators(c.right, known)
+	case *Inversed:
+		return checkGenerators(c.operand, known)
+	case *Named:
+		if !known[c.name] {
+			return fmt.Errorf("unknown generator %q", c.name)
+		}
+	case *Var:
+		return fmt.Errorf("variable %s in relator", c)
+	}
+	return nil
+}
+
+//Parses presentation like "<a, b | a*a, b*b*b, (a*b)*(a*b)>" (angle brackets are optional). Relators are parsed by
+//Parse; column of ParseError counts from beginning of "s".
+func ParsePresentation(s string) (*Presentation, error) {
+	body, offset := s, 0
+	if t := strings.TrimSpace(s); strings.HasPrefix(t, "<") && strings.HasSuffix(t, ">") {
+		offset = strings.Index(s, "<") + 1
+		body = s[offset:strings.LastIndex(s, ">")]
+	}
+	bar := strings.Index(body, "|")
+	if bar < 0 {
+		return nil, &ParseError{Column: len([]rune(s)) + 1, Msg: "expected |"}
+	}
+	var generators []string
+	for _, g := range strings.Split(body[:bar], ",") {
+		if g = strings.TrimSpace(g); g != "" {
+			generators = append(generators, g)
+		}
+	}
+	var relators []Element
+	pos := offset + bar + 1
+	for _, r := range strings.Split(body[bar+1:], ",") {
+		if strings.TrimSpace(r) != "" {
+			el, err := Parse(r)
+			if err != nil {
+				if pe, ok := err.(*ParseError); ok {
+					return nil, &ParseError{Column: pe.Column + len([]rune(s[:pos])), Msg: pe.Msg}
+				}
+				return nil, err
+			}
+			relators = append(relators, el)
+		}
+		pos += len(r) + 1
+	}
+	return NewPresentation(generators, relators...)
+}
+
+//Same as ParsePresentation but panics on error
+func MustParsePresentation(s string) *Presentation {
+	p, err := ParsePresentation(s)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+//returns generators of presentation
+func (p *Presentation) Generators() []string {
+	return append([]string(nil), p.generators...)
+}
+
+//returns relators of presentation
+func (p *Presentation) Relators() []Element {
+	r := make([]Element, len(p.relators))
+	for i, el := range p.relators {
+		r[i] = el.CloneLiteral()
+	}
+	return r
+}
+
+func (p *Presentation) String() string {
+	r := make([]string, len(p.relators))
+	for i, el := range p.relators {
+		r[i] = format(el)
+	}
+	return "<" + strings.Join(p.generators, ", ") + " | " + strings.Join(r, ", ") + ">"
+}
+
+//Turns relator number "i" to $e$ (Forward) or $e$ to relator number "i" (Backward). This is a step of proof valid
+//only in proofs verified by Verify methods of "p".
+func (p *Presentation) ApplyRelation(term Element, i int, dir Direction) Element {
+	n, err := p.TryApplyRelation(term, i, dir)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+//returns relator "i" of "p" or error
+func (p *Presentation) relator(i int) (Element, error) {
+	if i < 0 || i >= len(p.relators) {
+		return nil, fmt.Errorf("gt: no relator %d in %s", i, p)
+	}
+	return p.relators[i], nil
+}
+
+//Same as ApplyRelation but returns error instead of panicking
+func (p *Presentation) TryApplyRelation(term Element, i int, dir Direction) (Element, error) {
+	r, err := p.relator(i)
+	if err != nil {
+		return nil, err
+	}
+	s := Step{Rule: RuleRelation, Relator: i, Direction: dir, pres: p}
+	switch dir {
+	case Forward:
+		if !r.EqualLiteral(term) {
+			return nil, &StepError{Step: "ApplyRelation", Pattern: "$" + FormatLaTeX(r, LaTeXOptions{}) + "$", Term: term}
+		}
+		n := NewIdentity()
+		derive(n, term, s)
+		return n, nil
+	case Backward:
+		if _, ok := term.(*Identity); !ok {
+			return nil, &StepError{Step: "ApplyRelation", Pattern: "$e$", Term: term}
+		}
+		n := r.CloneLiteral()
+		derive(n, term, s)
+		return n, nil
+	}
+	return nil, fmt.Errorf("gt: invalid direction %d", int(dir))
+}
+
+//ApplyRelation as proof. This is a step of proof valid only in proofs verified by Verify methods of "p".
+func (p *Presentation) Relation(i int, dir Direction) func(Element) Element {
+	return func(el Element) Element {
+		return p.ApplyRelation(el, i, dir)
+	}
+}
+
+//Verify proof (forth, back) that $left = right$ in presented group
+func (p *Presentation) Verify(left, right Element, forth, back func(Element) Element) bool {
+	return p.Check(left, right, forth, back) == nil
+}
+
+//Verify proof "forth" that $left = right$ in presented group
+func (p *Presentation) VerifyForth(left, right Element, forth func(Element) Element) bool {
+	return p.CheckForth(left, right, forth) == nil
+}
+
+//Same as Verify but reports why the proof failed. Returns nil or *VerifyError.
+func (p *Presentation) Check(left, right Element, forth, back func(Element) Element) error {
+	if err := p.CheckForth(left, right, forth); err != nil {
+		return err
+	}
+	_, err := check(nil, right, left, back, "back", p)
+	return err
+}
+
+//Same as VerifyForth but reports why the proof failed. Returns nil or *VerifyError.
+func (p *Presentation) CheckForth(left, right Element, forth func(Element) Element) error {
+	_, err := check(nil, left, right, forth, "forth", p)
+	return err
+}
+
+//Same as CheckForth but also returns recorded proof when it is verified
+func (p *Presentation) VerifyProof(left, right Element, forth func(Element) Element) (*Proof, error) {
+	steps, err := check(nil, left, right, forth, "forth", p)
+	if err != nil {
+		return nil, err
+	}
+	return &Proof{Left: left.CloneLiteral(), Right: right.CloneLiteral(), Steps: steps}, nil
+}
+
+//Same as Replay but relations of presentation may be applied
+func (p *Presentation) Replay(left, right Element, steps []Step) error {
+	return replay(left, right, steps, p)
+}
@@ -0,0 +1,84 @@
+package gt
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestApplyRelation(t *testing.T) {
+	p := MustParsePresentation("<a, b | a*a, b*b*b, (a*b)*(a*b)>")
+	a, b := NewNamed("a"), NewNamed("b")
+
+	forth := Sequence(Associator, At(Path{MoveLeft}, p.Relation(0, Forward)), Simplifier)
+	back := Sequence(Unsimplifier(true), At(Path{MoveLeft}, p.Relation(0, Backward)), Unassociator)
+	left := Compose(a, Compose(a, b))
+	if err := p.Check(left, b, forth, back); err != nil {
+		t.Fatal("proof in presentation is not verified:", err)
+	}
+
+	var ve *VerifyError
+	if err := Check(left, b, forth, back); !errors.As(err, &ve) || ve.Kind != NotInPresentation {
+		t.Fatal("relation is accepted by free Check:", err)
+	}
+	q := MustParsePresentation("<a, b | a*a, b*b*b, (a*b)*(a*b)>")
+	if err := q.CheckForth(left, b, forth); !errors.As(err, &ve) || ve.Kind != NotInPresentation {
+		t.Fatal("relation is accepted by other presentation:", err)
+	}
+
+	if _, err := p.TryApplyRelation(Compose(a, b), 0, Forward); err == nil {
+		t.Fatal("relation is applied to wrong term")
+	}
+	if _, err := p.TryApplyRelation(NewIdentity(), 3, Backward); err == nil {
+		t.Fatal("missing relator is applied")
+	}
+}
+
+func TestPresentationProof(t *testing.T) {
+	p := MustParsePresentation("<a | a*a*a>")
+	a := NewNamed("a")
+	left := Compose(a, Compose(a, a))
+
+	proof, err := p.VerifyProof(left, NewIdentity(), p.Relation(0, Forward))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Replay(left, NewIdentity(), proof.Derivation()); err != nil {
+		t.Fatal("proof is not replayed:", err)
+	}
+	if err := Replay(left, NewIdentity(), proof.Derivation()); err == nil {
+		t.Fatal("relation is replayed without presentation")
+	}
+	if err := p.CheckForth(NewIdentity(), left, proof.Inverse().Forth()); err != nil {
+		t.Fatal("inverse proof is not verified:", err)
+	}
+
+	data, err := json.Marshal(proof.Derivation())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var steps []Step
+	if err := json.Unmarshal(data, &steps); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Replay(left, NewIdentity(), steps); err != nil {
+		t.Fatal("decoded proof is not replayed:", err, string(data))
+	}
+}
+
+func TestNewPresentation(t *testing.T) {
+	if _, err := ParsePresentation("<a | b*b>"); err == nil {
+		t.Fatal("unknown generator is accepted")
+	}
+	if _, err := ParsePresentation("<a | ?x*a>"); err == nil {
+		t.Fatal("variable is accepted")
+	}
+	var pe *ParseError
+	if _, err := ParsePresentation("<a | a*>"); !errors.As(err, &pe) || pe.Column != 8 {
+		t.Fatal("wrong error:", err)
+	}
+	p := MustParsePresentation("a, b | a*a, b^-1")
+	if p.String() != "<a, b | a*a, b^-1>" {
+		t.Fatal("wrong presentation:", p)
+	}
+}
@@ -0,0 +1,103 @@
+package gt
+
+import (
+	"bytes"
+	"fmt"
+)
+
+//Names of steps of proof
+const (
+	RuleAssociate    = "Associate"
+	RuleUnassociate  = "Unassociate"
+	RuleAnnihilate   = "Annihilate"
+	RuleUnannihilate = "Unannihilate"
+	RuleSimplify     = "Simplify"
+	RuleUnsimplify   = "Unsimplify"
+)
+
+//Application of step of proof to subterm at "Path"
+type Step struct {
+	//Name of step, one of Rule* constants
+	Rule string
+	Path Path
+	//Argument "el" of Unannihilate
+	Arg Element
+	//Argument "left" of Unannihilate and Unsimplify
+	Left bool
+	//Index of relator and direction of ApplyRelation
+	Relator   int
+	Direction Direction
+
+	//presentation of ApplyRelation
+	pres *Presentation
+}
+
+func (s Step) String() string {
+	switch s.Rule {
+	case RuleUnannihilate:
+		return fmt.Sprintf("%s(%s, %v) at %s", s.Rule, format(s.Arg), s.Left, s.Path)
+	case RuleUnsimplify:
+		return fmt.Sprintf("%s(%v) at %s", s.Rule, s.Left, s.Path)
+	case RuleRelation:
+		return fmt.Sprintf("%s(%d, %s) at %s", s.Rule, s.Relator, s.Direction, s.Path)
+	}
+	return fmt.Sprintf("%s at %s", s.Rule, s.Path)
+}
+
+//Recorded step of proof
+type ProofStep struct {
+	Step
+	//Subterm at "Path" before the step
+	Before Element
+	//Subterm at "Path" after the step
+	After Element
+}
+
+func (s ProofStep) String() string {
+	return fmt.Sprintf("%s: %s => %s", s.Step, format(s.Before), format(s.After))
+}
+
+//Recorded proof that $Left = Right$: steps in order of application
+type Proof struct {
+	Left  Element
+	Right Element
+	Steps []ProofStep
+}
+
+func (p *Proof) String() string {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "%s = %s", format(p.Left), format(p.Right))
+	for i, s := range p.Steps {
+		fmt.Fprintf(&b, "\n%d. %s", i+1, s)
+	}
+	return b.String()
+}
+
+//Same as CheckForth but also returns recorded proof when it is verified
+func VerifyProof(left, right Element, forth func(Element) Element) (*Proof, error) {
+	steps, err := check(nil, left, right, forth, "forth", nil)
+	if err != nil {
+		return nil, err
+	}
+	return &Proof{Left: left.CloneLiteral(), Right: right.CloneLiteral(), Steps: steps}, nil
+}
+
+//appends steps to copy of history so that histories of different elements never share memory
+func appendSteps(hist []ProofStep, steps ...ProofStep) []ProofStep {
+	if len(steps) == 0 {
+		return hist
+	}
+	h := make([]ProofStep, 0, len(hist)+len(steps))
+	h = append(h, hist...)
+	return append(h, steps...)
+}
+
+//moves steps made on subterm to its parent
+func under(hist []ProofStep, m Move) []ProofStep {
+	h := make([]ProofStep, len(hist))
+	for i, s := range hist {
+		s.Path = s.Path.under(m)
+		h[i] = s
+	}
+	return h
+}
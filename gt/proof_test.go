@@ -0,0 +1,83 @@
+package gt
+
+import (
+	"testing"
+)
+
+func TestVerifyProofRecordsSteps(t *testing.T) {
+	//$a \cdot b \cdot b^{-1} \cdot c = a\cdot c$
+	a, b, c := NewNamed("a"), NewNamed("b"), NewNamed("c")
+	d := Compose(a, Compose(b, Compose(Inverse(b), c)))
+	h := Compose(a, c)
+
+	id := func(a Element) Element { return a }
+
+	p, err := VerifyProof(d, h, func(x Element) Element {
+		return x.ToComposite().Map(id, func(el Element) Element {
+			y := el.ToComposite().Associate()
+			return y.Map(func(el Element) Element {
+				return el.ToComposite().Annihilate()
+			}, id).Simplify()
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []struct {
+		rule, path, before, after string
+	}{
+		{RuleAssociate, "right", "b*b^-1*c", "(b*b^-1)*c"},
+		{RuleAnnihilate, "right.left", "b*b^-1", "e"},
+		{RuleSimplify, "right", "e*c", "c"},
+	}
+	if len(p.Steps) != len(want) {
+		t.Fatalf("wrong number of steps:\n%s", p)
+	}
+	for i, w := range want {
+		s := p.Steps[i]
+		if s.Rule != w.rule || s.Path.String() != w.path || format(s.Before) != w.before || format(s.After) != w.after {
+			t.Fatalf("wrong step %d: %s", i+1, s)
+		}
+	}
+	if !p.Left.EqualLiteral(d) || !p.Right.EqualLiteral(h) {
+		t.Fatal("wrong proof statement")
+	}
+}
+
+func TestVerifyProofRecordsArguments(t *testing.T) {
+	a, b := NewNamed("a"), NewNamed("b")
+	id := func(a Element) Element { return a }
+
+	p, err := VerifyProof(a, Compose(Compose(b, Inverse(b)), a), func(x Element) Element {
+		return Unsimplify(x, true).Map(func(el Element) Element {
+			return el.ToIdentity().Unannihilate(b, false)
+		}, id)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(p.Steps) != 2 {
+		t.Fatalf("wrong number of steps:\n%s", p)
+	}
+	if s := p.Steps[0]; s.Rule != RuleUnsimplify || !s.Left || len(s.Path) != 0 {
+		t.Fatal("wrong step 1:", s)
+	}
+	if s := p.Steps[1]; s.Rule != RuleUnannihilate || s.Left || !s.Arg.EqualLiteral(b) || s.Path.String() != "left" {
+		t.Fatal("wrong step 2:", s)
+	}
+}
+
+func TestHistoryIsNotSharedWithArguments(t *testing.T) {
+	a, b := NewNamed("a"), NewNamed("b")
+	c := Compose(Compose(a, b), NewIdentity())
+	d := c.Simplify()
+	id := func(a Element) Element { return a }
+
+	if e := c.Map(id, id); len(e.history()) != 0 {
+		t.Fatal("'Simplify' changed history of its argument")
+	}
+	if e := Inverse(d).Map(id); len(e.history()) != 0 {
+		t.Fatal("'Inverse' shares history of its operand")
+	}
+}
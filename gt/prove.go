@@ -0,0 +1,164 @@
+package gt
+
+import (
+	"errors"
+	"fmt"
+)
+
+//Elements are not equal in free group so there is no proof of their equality
+var ErrNotEqual = errors.New("gt: elements are not equal in free group")
+
+//Searches proof that $left = right$ made of steps Associate, Unassociate, Annihilate, Unannihilate, Simplify and
+//Unsimplify. Fails with ErrNotEqual if elements are not equal in free group. "VerifyForth" accepts proof.Forth().
+func Prove(left, right Element) (*Proof, error) {
+	if !Equal(left, right) {
+		return nil, fmt.Errorf("%w: %s != %s", ErrNotEqual, Normalize(left), Normalize(right))
+	}
+	pl, err := NormalizeProof(left)
+	if err != nil {
+		return nil, err
+	}
+	pr, err := NormalizeProof(right)
+	if err != nil {
+		return nil, err
+	}
+
+	//left to normal form, then back from normal form to right
+	p := &prover{term: pl.Left}
+	for _, s := range pl.Steps {
+		p.apply(s.Step)
+	}
+	for _, s := range pr.Inverse().Steps {
+		p.apply(s.Step)
+	}
+	if !p.term.EqualLiteral(right) {
+		panic(fmt.Sprintf("gt: prover proved %s = %s instead of %s", pl.Left, p.term, right))
+	}
+	return &Proof{Left: pl.Left, Right: p.term, Steps: p.steps}, nil
+}
+
+//Returns proof "forth" applying steps of proof one after another. This is a step of proof.
+func (p *Proof) Forth() func(Element) Element {
+	steps := p.Derivation()
+	return func(el Element) Element {
+		for _, s := range steps {
+			el = s.Apply(el)
+		}
+		return el
+	}
+}
+
+//Returns proof that $Right = Left$ made of inverses of steps of "p". Subterms before and after steps missing in
+//decoded proof are rebuilt by replaying it from Left. Panics if proof can't be replayed, see TryInverse.
+func (p *Proof) Inverse() *Proof {
+	q, err := p.TryInverse()
+	if err != nil {
+		panic(err)
+	}
+	return q
+}
+
+//Same as Inverse but returns *ReplayError instead of panicking
+func (p *Proof) TryInverse() (*Proof, error) {
+	steps, err := p.subterms()
+	if err != nil {
+		return nil, err
+	}
+	q := &Proof{Left: p.Right, Right: p.Left, Steps: make([]ProofStep, len(steps))}
+	for i, s := range steps {
+		q.Steps[len(steps)-1-i] = ProofStep{Step: s.inverse(), Before: s.After, After: s.Before}
+	}
+	return q, nil
+}
+
+//returns steps of proof with subterms before and after them, replaying proof from Left if some are missing
+func (p *Proof) subterms() ([]ProofStep, error) {
+	complete := true
+	for _, s := range p.Steps {
+		complete = complete && s.Before != nil && s.After != nil
+	}
+	if complete {
+		return p.Steps, nil
+	}
+	term, err := copyTerm(p.Left)
+	if err != nil {
+		return nil, err
+	}
+	steps := append([]ProofStep(nil), p.Steps...)
+	for i := range steps {
+		s := &steps[i]
+		before, err := subterm(term, s.Path)
+		if err != nil {
+			return nil, &ReplayError{Index: i, Step: s.Step, Err: err}
+		}
+		if term, err = applyStep(term, s.Step, s.pres); err != nil {
+			return nil, &ReplayError{Index: i, Step: s.Step, Err: err}
+		}
+		after, _ := subterm(term, s.Path)
+		if s.Before == nil {
+			s.Before = before
+		}
+		if s.After == nil {
+			s.After = after
+		}
+	}
+	return steps, nil
+}
+
+//returns step which undoes recorded step
+func (s ProofStep) inverse() Step {
+	n := Step{Path: s.Path}
+	switch s.Rule {
+	case RuleAssociate:
+		n.Rule = RuleUnassociate
+	case RuleUnassociate:
+		n.Rule = RuleAssociate
+	case RuleAnnihilate:
+		//same order of checks as in TryAnnihilate
+		c := s.Before.(*Composite)
+		n.Rule = RuleUnannihilate
+		if r, ok := c.right.(*Inversed); ok && r.operand.EqualLiteral(c.left) {
+			n.Arg, n.Left = c.left, false
+		} else {
+			n.Arg, n.Left = c.right, true
+		}
+	case RuleUnannihilate:
+		n.Rule = RuleAnnihilate
+	case RuleSimplify:
+		//same order of checks as in TrySimplify
+		c := s.Before.(*Composite)
+		_, ri := c.right.(*Identity)
+		n.Rule, n.Left = RuleUnsimplify, !ri
+	case RuleUnsimplify:
+		n.Rule = RuleSimplify
+	case RuleDoubleInverse:
+		n.Rule = RuleUndoubleInverse
+	case RuleUndoubleInverse:
+		n.Rule = RuleDoubleInverse
+	case RuleDistributeOverProduct:
+		n.Rule = RuleUndistributeOverProduct
+	case RuleUndistributeOverProduct:
+		n.Rule = RuleDistributeOverProduct
+	case RuleRelation:
+		n.Rule, n.Relator, n.pres = RuleRelation, s.Relator, s.pres
+		n.Direction = Forward
+		if s.Direction == Forward {
+			n.Direction = Backward
+		}
+	default:
+		panic(fmt.Sprintf("gt: can't inverse step %s", s.Step))
+	}
+	return n
+}
+
+func equalWords(a, b []letter) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
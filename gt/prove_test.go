@@ -0,0 +1,62 @@
+package gt
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestProve(t *testing.T) {
+	tests := []struct {
+		left, right string
+	}{
+		{"a*(b*(b^-1*c))", "a*c"},
+		{"a*c", "a*(b*(b^-1*c))"},
+		{"a", "a"},
+		{"e", "a*a^-1"},
+		{"(a*b)^-1", "b^-1*a^-1"},
+		{"a^-1^-1", "a"},
+		{"((a*b)*(c*d))^-1^-1", "a*b*c*d"},
+		{"(a^-1*(b*c^-1)^-1)^-1*e^-1", "b*c^-1*a"},
+		{"(x*y)*(y^-1*x^-1)", "e*e"},
+		{"(a*b*a^-1)^-1", "a*b^-1*a^-1"},
+	}
+	for _, tt := range tests {
+		left, right := MustParse(tt.left), MustParse(tt.right)
+		p, err := Prove(left, right)
+		if err != nil {
+			t.Fatalf("%s = %s: %v", tt.left, tt.right, err)
+		}
+		if err := CheckForth(left, right, p.Forth()); err != nil {
+			t.Fatalf("%s = %s: proof is not verified: %v\n%s", tt.left, tt.right, err, p)
+		}
+		if err := Check(left, right, p.Forth(), p.Inverse().Forth()); err != nil {
+			t.Fatalf("%s = %s: inverse proof is not verified: %v", tt.left, tt.right, err)
+		}
+		if err := Replay(left, right, p.Derivation()); err != nil {
+			t.Fatalf("%s = %s: proof is not replayed: %v", tt.left, tt.right, err)
+		}
+	}
+}
+
+func TestProveFailsOnNotEqual(t *testing.T) {
+	tests := []struct {
+		left, right string
+	}{
+		{"a*b", "b*a"},
+		{"a", "e"},
+		{"(a*b)^-1", "a^-1*b^-1"},
+	}
+	for _, tt := range tests {
+		if _, err := Prove(MustParse(tt.left), MustParse(tt.right)); !errors.Is(err, ErrNotEqual) {
+			t.Fatalf("%s = %s: %v", tt.left, tt.right, err)
+		}
+	}
+}
+
+func TestAnnihilateInversedOfInversed(t *testing.T) {
+	//$(a^{-1})^{-1}\cdot a^{-1}$ is $x^{-1}\cdot x$ with $x = a^{-1}$
+	c := MustParse("a^-1^-1*a^-1").(*Composite)
+	if _, err := c.TryAnnihilate(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,181 @@
+package gt
+
+import (
+	"fmt"
+)
+
+//Error of step "Index" (counting from 0) of replayed proof
+type ReplayError struct {
+	Index int
+	Step  Step
+	Err   error
+}
+
+func (e *ReplayError) Error() string {
+	return fmt.Sprintf("step %d (%s): %v", e.Index+1, e.Step, e.Err)
+}
+
+func (e *ReplayError) Unwrap() error {
+	return e.Err
+}
+
+//Checks data-only proof that $left = right$ applying steps by itself, so no caller's code is executed.
+//Returns nil, *ReplayError or *VerifyError.
+func Replay(left, right Element, steps []Step) error {
+	return replay(left, right, steps, nil)
+}
+
+func replay(left, right Element, steps []Step, pres *Presentation) error {
+	l, err := copyTerm(left)
+	if err != nil {
+		return &VerifyError{Kind: ForeignElement, Proof: "steps", Err: err}
+	}
+	r, err := copyTerm(right)
+	if err != nil {
+		return &VerifyError{Kind: ForeignElement, Proof: "steps", Err: err}
+	}
+	for i, s := range steps {
+		if l, err = applyStep(l, s, pres); err != nil {
+			return &ReplayError{Index: i, Step: s, Err: err}
+		}
+	}
+	if !l.EqualLiteral(r) {
+		return &VerifyError{Kind: NotEqual, Proof: "steps", Expected: r, Actual: l, Diff: Diff(r, l)}
+	}
+	return nil
+}
+
+//Returns data-only steps of proof suitable for Replay
+func (p *Proof) Derivation() []Step {
+	steps := make([]Step, len(p.Steps))
+	for i, s := range p.Steps {
+		steps[i] = s.Step
+	}
+	return steps
+}
+
+//applies step to subterm of "term" at step's path; relations are taken from "pres". Result shares subterms off the
+//path with "term".
+func applyStep(term Element, s Step, pres *Presentation) (Element, error) {
+	if s.Rule == RuleRelation && pres == nil {
+		return nil, fmt.Errorf("%s requires presentation", s.Rule)
+	}
+	var arg Element
+	if s.Rule == RuleUnannihilate {
+		if s.Arg == nil {
+			return nil, fmt.Errorf("%s requires argument", s.Rule)
+		}
+		var err error
+		if arg, err = copyTerm(s.Arg); err != nil {
+			return nil, err
+		}
+	}
+	return rewrite(term, s.Path, func(el Element) (Element, error) {
+		switch s.Rule {
+		case RuleAssociate, RuleUnassociate, RuleAnnihilate, RuleSimplify:
+			c, err := AsComposite(el)
+			if err != nil {
+				return nil, err
+			}
+			switch s.Rule {
+			case RuleAssociate:
+				return c.TryAssociate()
+			case RuleUnassociate:
+				return c.TryUnassociate()
+			case RuleAnnihilate:
+				return c.TryAnnihilate()
+			}
+			return c.TrySimplify()
+		case RuleDoubleInverse, RuleDistributeOverProduct:
+			c, err := AsInversed(el)
+			if err != nil {
+				return nil, err
+			}
+			if s.Rule == RuleDoubleInverse {
+				return c.TryDoubleInverse()
+			}
+			return c.TryDistributeOverProduct()
+		case RuleUndistributeOverProduct:
+			c, err := AsComposite(el)
+			if err != nil {
+				return nil, err
+			}
+			return c.TryUndistributeOverProduct()
+		case RuleUndoubleInverse:
+			return UndoubleInverse(el), nil
+		case RuleUnannihilate:
+			c, err := AsIdentity(el)
+			if err != nil {
+				return nil, err
+			}
+			return c.Unannihilate(arg, s.Left), nil
+		case RuleUnsimplify:
+			return Unsimplify(el, s.Left), nil
+		case RuleRelation:
+			return pres.TryApplyRelation(el, s.Relator, s.Direction)
+		}
+		return nil, fmt.Errorf("unknown rule %q", s.Rule)
+	})
+}
+
+//replaces subterm of "term" at "path" by its image under "f"
+func rewrite(term Element, path Path, f func(Element) (Element, error)) (Element, error) {
+	if len(path) == 0 {
+		return f(term)
+	}
+	switch c := term.(type) {
+	case *Composite:
+		switch path[0] {
+		case MoveLeft:
+			l, err := rewrite(c.left, path[1:], f)
+			if err != nil {
+				return nil, err
+			}
+			return compose(l, c.right), nil
+		case MoveRight:
+			r, err := rewrite(c.right, path[1:], f)
+			if err != nil {
+				return nil, err
+			}
+			return compose(c.left, r), nil
+		}
+	case *Inversed:
+		if path[0] == MoveOperand {
+			op, err := rewrite(c.operand, path[1:], f)
+			if err != nil {
+				return nil, err
+			}
+			return inverse(op), nil
+		}
+	}
+	return nil, fmt.Errorf("%s has no %s subterm", format(term), path[0])
+}
+
+//makes literal copy of element built of this package's types only
+func copyTerm(el Element) (Element, error) {
+	switch c := el.(type) {
+	case *Composite:
+		l, err := copyTerm(c.left)
+		if err != nil {
+			return nil, err
+		}
+		r, err := copyTerm(c.right)
+		if err != nil {
+			return nil, err
+		}
+		return compose(l, r), nil
+	case *Inversed:
+		op, err := copyTerm(c.operand)
+		if err != nil {
+			return nil, err
+		}
+		return inverse(op), nil
+	case *Named:
+		return NewNamed(c.name), nil
+	case *Var:
+		return NewVar(c.name), nil
+	case *Identity:
+		return NewIdentity(), nil
+	}
+	return nil, fmt.Errorf("unsupported element type %T", el)
+}
@@ -0,0 +1,100 @@
+package gt
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestReplay(t *testing.T) {
+	//$a \cdot b \cdot b^{-1} \cdot c = a\cdot c$
+	a, b, c := NewNamed("a"), NewNamed("b"), NewNamed("c")
+	d := Compose(a, Compose(b, Compose(Inverse(b), c)))
+	h := Compose(a, c)
+
+	steps := []Step{
+		{Rule: RuleAssociate, Path: Path{MoveRight}},
+		{Rule: RuleAnnihilate, Path: Path{MoveRight, MoveLeft}},
+		{Rule: RuleSimplify, Path: Path{MoveRight}},
+	}
+	if err := Replay(d, h, steps); err != nil {
+		t.Fatal(err)
+	}
+
+	back := []Step{
+		{Rule: RuleUnsimplify, Path: Path{MoveRight}, Left: true},
+		{Rule: RuleUnannihilate, Path: Path{MoveRight, MoveLeft}, Arg: b},
+		{Rule: RuleUnassociate, Path: Path{MoveRight}},
+	}
+	if err := Replay(h, d, back); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReplayRecordedProof(t *testing.T) {
+	a, b := NewNamed("a"), NewNamed("b")
+	id := func(a Element) Element { return a }
+	right := Compose(Compose(b, Inverse(b)), a)
+
+	p, err := VerifyProof(a, right, func(x Element) Element {
+		return Unsimplify(x, true).Map(func(el Element) Element {
+			return el.ToIdentity().Unannihilate(b, false)
+		}, id)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Replay(a, right, p.Derivation()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReplayRejectsWrongSteps(t *testing.T) {
+	a, b := NewNamed("a"), NewNamed("b")
+
+	var re *ReplayError
+	err := Replay(Compose(a, b), a, []Step{{Rule: RuleSimplify}})
+	if !errors.As(err, &re) || re.Index != 0 {
+		t.Fatal("wrong step is replayed:", err)
+	}
+	var se *StepError
+	if !errors.As(err, &se) || se.Step != "Simplify" {
+		t.Fatal("StepError is not reported:", err)
+	}
+
+	err = Replay(Compose(a, b), a, []Step{{Rule: RuleSimplify, Path: Path{MoveOperand}}})
+	if !errors.As(err, &re) {
+		t.Fatal("wrong path is replayed:", err)
+	}
+
+	err = Replay(a, b, []Step{{Rule: "Teleport"}})
+	if !errors.As(err, &re) {
+		t.Fatal("unknown rule is replayed:", err)
+	}
+
+	var ve *VerifyError
+	err = Replay(a, b, []Step{{Rule: RuleUnsimplify}})
+	if !errors.As(err, &ve) || ve.Kind != NotEqual {
+		t.Fatal("wrong result is accepted:", err)
+	}
+}
+
+//Named element which is literally equal to anything
+type liar struct {
+	*Named
+}
+
+func (l liar) EqualLiteral(Element) bool { return true }
+
+func TestReplayDoesNotRunForeignCode(t *testing.T) {
+	a, b := NewNamed("a"), NewNamed("b")
+
+	var ve *VerifyError
+	for _, tt := range [][2]Element{{liar{a}, b}, {b, compose(a, liar{a})}, {&Inversed{}, b}} {
+		if err := Replay(tt[0], tt[1], nil); !errors.As(err, &ve) || ve.Kind != ForeignElement {
+			t.Fatal("foreign element is accepted:", err)
+		}
+	}
+	if err := Replay(NewIdentity(), b, []Step{{Rule: RuleUnannihilate, Arg: liar{a}}}); err == nil {
+		t.Fatal("foreign argument is accepted")
+	}
+}
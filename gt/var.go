@@ -0,0 +1,93 @@
+package gt
+
+//Pattern variable: stands for any element in Match and Substitute
+type Var struct {
+	element
+	name string
+}
+
+func (c *Var) ToVar() *Var { return c }
+
+//returns name of variable
+func (c *Var) Name() string {
+	return c.name
+}
+
+//Creates new pattern variable
+func NewVar(name string) *Var {
+	n := &Var{
+		name: name,
+	}
+	n.init()
+	return n
+}
+
+//Checks whether two elements are equal literally (although same() may return false)
+func (c *Var) EqualLiteral(other Element) bool {
+	if o, ok := other.(*Var); ok {
+		return o.name == c.name
+	}
+	return false
+}
+
+//Makes literal clone of element (although same() will return false)
+func (c *Var) CloneLiteral() Element {
+	return NewVar(c.name)
+}
+
+//Elements substituted for variables
+type Substitution map[string]Element
+
+//Matches "term" against "pattern" whose variables stand for any elements. Returns substitution which turns
+//"pattern" into "term".
+func Match(pattern, term Element) (Substitution, bool) {
+	sub := Substitution{}
+	if !match(pattern, term, sub) {
+		return nil, false
+	}
+	return sub, true
+}
+
+func match(pattern, term Element, sub Substitution) bool {
+	switch p := pattern.(type) {
+	case *Var:
+		if s, ok := sub[p.name]; ok {
+			return s.EqualLiteral(term)
+		}
+		t, err := copyTerm(term)
+		if err != nil {
+			return false
+		}
+		sub[p.name] = t
+		return true
+	case *Composite:
+		if t, ok := term.(*Composite); ok {
+			return match(p.left, t.left, sub) && match(p.right, t.right, sub)
+		}
+		return false
+	case *Inversed:
+		if t, ok := term.(*Inversed); ok {
+			return match(p.operand, t.operand, sub)
+		}
+		return false
+	}
+	return pattern.EqualLiteral(term)
+}
+
+//Returns "term" with variables replaced by elements of "sub". Variables missing in "sub" are not replaced.
+func Substitute(term Element, sub Substitution) Element {
+	switch c := term.(type) {
+	case *Composite:
+		return Compose(Substitute(c.left, sub), Substitute(c.right, sub))
+	case *Inversed:
+		return Inverse(Substitute(c.operand, sub))
+	case *Var:
+		if s, ok := sub[c.name]; ok {
+			return s.CloneLiteral()
+		}
+	}
+	if term == nil {
+		return nil
+	}
+	return term.CloneLiteral()
+}
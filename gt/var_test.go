@@ -0,0 +1,57 @@
+package gt
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestMatch(t *testing.T) {
+	pattern := MustParse("?x*(?y^-1*?x)*a")
+
+	sub, ok := Match(pattern, MustParse("(b*c)*((d*e)^-1*(b*c))*a"))
+	if !ok {
+		t.Fatal("pattern is not matched")
+	}
+	if len(sub) != 2 || !sub["x"].EqualLiteral(MustParse("b*c")) || !sub["y"].EqualLiteral(MustParse("d*e")) {
+		t.Fatal("wrong substitution", sub)
+	}
+
+	for _, src := range []string{
+		"(b*c)*((d*e)^-1*(c*b))*a",
+		"(b*c)*((d*e)^-1*(b*c))*b",
+		"(b*c)*(d*(b*c))*a",
+		"b",
+	} {
+		if sub, ok := Match(pattern, MustParse(src)); ok {
+			t.Fatalf("%s matches %s with %v", src, pattern, sub)
+		}
+	}
+}
+
+func TestSubstitute(t *testing.T) {
+	pattern := MustParse("?x*(?y^-1*?x)*a")
+	term := MustParse("(b*c)*(e^-1*(b*c))*a")
+
+	sub, ok := Match(pattern, term)
+	if !ok {
+		t.Fatal("pattern is not matched")
+	}
+	if el := Substitute(pattern, sub); !el.EqualLiteral(term) || el.Same(term) {
+		t.Fatal("wrong substitution result", el)
+	}
+	if el := Substitute(pattern, Substitution{"x": NewNamed("z")}); !el.EqualLiteral(MustParse("z*(?y^-1*z)*a")) {
+		t.Fatal("wrong partial substitution result", el)
+	}
+}
+
+func TestVarIsLetter(t *testing.T) {
+	if !Equal(MustParse("?x*(?y*?x)^-1"), MustParse("?x*?x^-1*?y^-1")) {
+		t.Fatal("variables are not equal in free group")
+	}
+	if Equal(MustParse("?x"), MustParse("x")) {
+		t.Fatal("variable is equal to named element")
+	}
+	if fmt.Sprint(Normalize(MustParse("(?x*a)^-1"))) != "a^-1*?x^-1" {
+		t.Fatal("wrong normal form")
+	}
+}